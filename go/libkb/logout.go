@@ -17,6 +17,33 @@ func (mctx MetaContext) LogoutKeepSecrets() (err error) {
 type LogoutOptions struct {
 	KeepSecrets bool
 	Force       bool
+
+	// Deprovision, if set, runs the full device-deprovisioning sequence
+	// (revoke current device's keys, clear the SKB keyring, nuke local
+	// databases) before falling through to the regular logout teardown,
+	// instead of requiring a separate DeprovisionEngine pass.
+	Deprovision bool
+	// RevokeCurrentDevice requests that the current device's sibkey/subkey
+	// be revoked as part of deprovisioning. Only consulted when
+	// Deprovision is set; a caller that already revoked out-of-band (e.g.
+	// because the device was revoked from another client) can leave this
+	// false to skip a redundant revoke.
+	RevokeCurrentDevice bool
+
+	// Reason records why this logout is happening, so logout hooks and
+	// HandleLogout subscribers can distinguish a temporary switch from a
+	// permanent revocation. Defaults to LogoutReasonUserRequested.
+	Reason LogoutReason
+}
+
+// DeviceRevoker is implemented by the engine layer and installed on
+// GlobalContext so that libkb's deprovision sequence can post a revoke for
+// the current device without libkb importing the engine package.
+type DeviceRevoker interface {
+	RevokeDevice(mctx MetaContext, deviceID keybase1.DeviceID, revokeCurrentDevice bool) error
+	// OtherDeviceCount reports how many other devices the logged-in user
+	// has provisioned, for CanDeprovision's "you'll lose access" warning.
+	OtherDeviceCount(mctx MetaContext) (int, error)
 }
 
 func (mctx MetaContext) LogoutWithOptions(options LogoutOptions) (err error) {
@@ -45,6 +72,15 @@ func (mctx MetaContext) LogoutUsernameWithOptions(username NormalizedUsername, o
 		}
 	}
 
+	if options.Deprovision {
+		options.Reason = LogoutReasonDeprovision
+		if err = mctx.runDeprovisionSequence(username, options); err != nil {
+			return err
+		}
+	} else if options.Force && options.Reason == LogoutReasonUserRequested {
+		options.Reason = LogoutReasonForced
+	}
+
 	var keychainMode KeychainMode
 	keychainMode, err = g.ActiveDevice.ClearGetKeychainMode()
 	if err != nil {
@@ -53,8 +89,14 @@ func (mctx MetaContext) LogoutUsernameWithOptions(username NormalizedUsername, o
 
 	g.LocalSigchainGuard().Clear(mctx.Ctx(), "Logout")
 
-	mctx.Debug("+ MetaContext#logoutWithSecretKill: calling logout hooks")
-	g.CallLogoutHooks(mctx)
+	mctx.Debug("+ MetaContext#logoutWithSecretKill: calling logout hooks (reason=%s)", options.Reason)
+	if g.LogoutHooks != nil {
+		if hookErr := g.LogoutHooks.RunAll(mctx, options.Reason, options); hookErr != nil {
+			return hookErr
+		}
+	} else {
+		g.CallLogoutHooks(mctx)
+	}
 	mctx.Debug("- MetaContext#logoutWithSecretKill: called logout hooks")
 
 	g.ClearPerUserKeyring()
@@ -74,8 +116,13 @@ func (mctx MetaContext) LogoutUsernameWithOptions(username NormalizedUsername, o
 		mctx.Debug("Logout ConfigReload error: %s", err)
 	}
 
-	// send logout notification
-	g.NotifyRouter.HandleLogout(mctx.Ctx())
+	// send logout notification; deprovisioning is a distinct event from a
+	// plain logout since the account itself is gone, not just this session.
+	if options.Deprovision {
+		g.NotifyRouter.HandleDeprovision(mctx.Ctx(), username.String())
+	} else {
+		g.NotifyRouter.HandleLogoutWithReason(mctx.Ctx(), options.Reason.String())
+	}
 
 	g.FeatureFlags.Clear()
 
@@ -90,6 +137,64 @@ func (mctx MetaContext) LogoutUsernameWithOptions(username NormalizedUsername, o
 
 	g.Pegboard.OnLogout(mctx)
 
+	if g.Profiles != nil {
+		if perr := g.Profiles.DeleteProfile(mctx, username, options); perr != nil {
+			mctx.Debug("MetaContext#LogoutUsernameWithOptions: DeleteProfile error: %s", perr)
+		}
+	}
+
+	return nil
+}
+
+// runDeprovisionSequence revokes the current device (if requested and a
+// device is active; no connectivity check is made, so an offline revoke
+// fails the sequence unless options.Force is set), clears the SKB keyring for username, and nukes the local and
+// chat databases. It runs before the regular logout teardown so that the
+// rest of LogoutUsernameWithOptions (hooks, cache flush, secret-store
+// clear) still applies afterwards.
+func (mctx MetaContext) runDeprovisionSequence(username NormalizedUsername, options LogoutOptions) (err error) {
+	defer mctx.Trace(fmt.Sprintf("MetaContext#runDeprovisionSequence(%s)", username), func() error { return err })()
+
+	g := mctx.G()
+
+	if options.RevokeCurrentDevice && g.ActiveDevice.Valid() {
+		if g.DeviceRevoker == nil {
+			mctx.Debug("runDeprovisionSequence: no DeviceRevoker installed, skipping remote revoke")
+		} else if deviceID := g.ActiveDevice.DeviceID(); !deviceID.IsNil() {
+			if err = g.DeviceRevoker.RevokeDevice(mctx, deviceID, true); err != nil {
+				mctx.Debug("runDeprovisionSequence: RevokeDevice error: %s", err)
+				if !options.Force {
+					return err
+				}
+			}
+		}
+	}
+
+	if err = ClearSKB(mctx, username); err != nil {
+		mctx.Debug("runDeprovisionSequence: ClearSKB error: %s", err)
+		if !options.Force {
+			return err
+		}
+	}
+
+	if g.LocalDb != nil {
+		if err = g.LocalDb.Nuke(); err != nil {
+			mctx.Debug("runDeprovisionSequence: LocalDb.Nuke error: %s", err)
+			if !options.Force {
+				return err
+			}
+		}
+	}
+
+	if g.LocalChatDb != nil {
+		if err = g.LocalChatDb.Nuke(); err != nil {
+			mctx.Debug("runDeprovisionSequence: LocalChatDb.Nuke error: %s", err)
+			if !options.Force {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -99,7 +204,7 @@ func (m MetaContext) logoutSecretStore(username NormalizedUsername, noKillSecret
 	g.secretStoreMu.Lock()
 	defer g.secretStoreMu.Unlock()
 
-	if g.secretStore == nil || username.IsNil() {
+	if username.IsNil() {
 		return
 	}
 
@@ -108,8 +213,21 @@ func (m MetaContext) logoutSecretStore(username NormalizedUsername, noKillSecret
 		return
 	}
 
-	if err := g.secretStore.ClearSecret(m, username); err != nil {
-		m.Debug("clear stored secret error: %s", err)
+	var backend SecretStoreBackend
+	if g.SecretStoreBackends != nil {
+		backend = g.SecretStoreBackends.Active()
+	}
+	if backend != nil {
+		if err := backend.ClearSecret(m, username); err != nil {
+			m.Debug("clear stored secret error (backend %s): %s", backend.Name(), err)
+			return
+		}
+	} else if g.secretStore != nil {
+		if err := g.secretStore.ClearSecret(m, username); err != nil {
+			m.Debug("clear stored secret error: %s", err)
+			return
+		}
+	} else {
 		return
 	}
 
@@ -155,12 +273,39 @@ func (mctx MetaContext) LogoutSelfCheck() error {
 	mctx.Debug("LogoutSelfCheck: should log out? %v", logout)
 	if logout {
 		mctx.Debug("LogoutSelfCheck: logging out...")
-		return mctx.LogoutKillSecrets()
+		return mctx.LogoutWithOptions(LogoutOptions{Reason: LogoutReasonServerRevoked})
 	}
 
 	return nil
 }
 
+// CanDeprovision reports whether it's safe to deprovision this device,
+// layering a "you have no other devices, you'll lose access to your
+// account" warning on top of the regular CanLogout checks.
+func CanDeprovision(mctx MetaContext) (res keybase1.CanLogoutRes) {
+	res = CanLogout(mctx)
+	if !res.CanLogout {
+		return res
+	}
+
+	g := mctx.G()
+	if g.DeviceRevoker == nil {
+		return res
+	}
+
+	count, err := g.DeviceRevoker.OtherDeviceCount(mctx)
+	if err != nil {
+		mctx.Debug("CanDeprovision: OtherDeviceCount error: %s", err)
+		return res
+	}
+
+	if count == 0 {
+		res.Reason = "You have no other devices. Deprovisioning this one means you'll lose access to your account unless you have your paper key."
+	}
+
+	return res
+}
+
 func CanLogout(mctx MetaContext) (res keybase1.CanLogoutRes) {
 	if !mctx.G().ActiveDevice.Valid() {
 		mctx.Debug("CanLogout: looks like user is not logged in")
@@ -174,6 +319,16 @@ func CanLogout(mctx MetaContext) (res keybase1.CanLogoutRes) {
 		return res
 	}
 
+	if backends := mctx.G().SecretStoreBackends; backends != nil {
+		if backend := backends.Active(); backend != nil {
+			username := mctx.G().ActiveDevice.Username(mctx)
+			if ok, reason := backend.CanEraseWithoutDataLoss(mctx, username); !ok {
+				mctx.Debug("CanLogout: backend %s refused: %s", backend.Name(), reason)
+				return keybase1.CanLogoutRes{CanLogout: false, Reason: reason}
+			}
+		}
+	}
+
 	if err := CheckCurrentUIDDeviceID(mctx); err != nil {
 		switch err.(type) {
 		case DeviceNotFoundError, UserNotFoundError,
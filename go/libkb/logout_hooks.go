@@ -0,0 +1,128 @@
+package libkb
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LogoutReason records *why* a logout is happening, so subscribed hooks
+// (chat, KBFS, teams) can tell "temporary switch, keep caches warm" apart
+// from "revoked, burn everything".
+type LogoutReason int
+
+const (
+	LogoutReasonUserRequested LogoutReason = iota
+	LogoutReasonServerRevoked
+	LogoutReasonDeprovision
+	LogoutReasonSwitch
+	LogoutReasonShutdown
+	LogoutReasonForced
+)
+
+func (r LogoutReason) String() string {
+	switch r {
+	case LogoutReasonUserRequested:
+		return "user-requested"
+	case LogoutReasonServerRevoked:
+		return "server-revoked"
+	case LogoutReasonDeprovision:
+		return "deprovision"
+	case LogoutReasonSwitch:
+		return "switch"
+	case LogoutReasonShutdown:
+		return "shutdown"
+	case LogoutReasonForced:
+		return "forced"
+	default:
+		return "unknown"
+	}
+}
+
+// LogoutHook replaces the untyped logout hook fan-out with named, ordered,
+// optionally-critical entries. Hooks run in descending Priority order; two
+// hooks with equal priority run in registration order.
+type LogoutHook struct {
+	Name string
+	// Priority controls run order: higher runs first. Hooks that must tear
+	// down before others observe a logged-out state (e.g. clearing a
+	// session token) should use a high priority.
+	Priority int
+	// Critical hooks abort the logout on error, unless the caller passed
+	// Force: true in LogoutOptions: the per-user keyring, caches, secret
+	// store and profile entry are then left as they were. The active device
+	// and the local sigchain guard (and, when deprovisioning, the SKB keyring
+	// and local databases) are cleared before any hook runs, so an aborted
+	// logout still leaves those cleared. Non-critical hook failures
+	// are logged and otherwise ignored.
+	Critical bool
+	// Timeout bounds how long this hook is allowed to run; zero means no
+	// per-hook timeout is enforced.
+	Timeout time.Duration
+	Run     func(mctx MetaContext, reason LogoutReason) error
+}
+
+// LogoutHookRegistry is the ordered, named replacement for the old
+// `[]func(MetaContext)` hook list on GlobalContext.
+type LogoutHookRegistry struct {
+	sync.Mutex
+	hooks []LogoutHook
+}
+
+func NewLogoutHookRegistry() *LogoutHookRegistry {
+	return &LogoutHookRegistry{}
+}
+
+// Register adds a hook. Call during subsystem init (chat, KBFS, teams,
+// etc.); registration order only matters as a tiebreaker among hooks that
+// share a Priority.
+func (r *LogoutHookRegistry) Register(hook LogoutHook) {
+	r.Lock()
+	defer r.Unlock()
+	r.hooks = append(r.hooks, hook)
+}
+
+// RunAll executes every registered hook in priority order, logging each
+// hook's duration and error with mctx.Debug. It returns the first Critical hook's error (unless options.Force is
+// set), after which no further hooks run.
+func (r *LogoutHookRegistry) RunAll(mctx MetaContext, reason LogoutReason, options LogoutOptions) error {
+	r.Lock()
+	ordered := make([]LogoutHook, len(r.hooks))
+	copy(ordered, r.hooks)
+	r.Unlock()
+
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Priority > ordered[j].Priority })
+
+	for _, hook := range ordered {
+		start := mctx.G().Clock().Now()
+		err := runLogoutHookWithTimeout(mctx, hook, reason)
+		mctx.Debug("LogoutHookRegistry: hook %q (reason=%s) took %s, err=%v",
+			hook.Name, reason, mctx.G().Clock().Now().Sub(start), err)
+
+		if err == nil {
+			continue
+		}
+		if hook.Critical && !options.Force {
+			return fmt.Errorf("logout hook %q failed critically: %w", hook.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func runLogoutHookWithTimeout(mctx MetaContext, hook LogoutHook, reason LogoutReason) (err error) {
+	if hook.Timeout == 0 {
+		return hook.Run(mctx, reason)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- hook.Run(mctx, reason) }()
+
+	select {
+	case err = <-done:
+		return err
+	case <-time.After(hook.Timeout):
+		return fmt.Errorf("logout hook %q timed out after %s", hook.Name, hook.Timeout)
+	}
+}
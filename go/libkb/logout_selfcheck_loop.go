@@ -0,0 +1,96 @@
+package libkb
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	selfCheckBaseInterval = 4 * time.Hour
+	selfCheckJitterFrac   = 0.25
+	selfCheckMaxBackoff   = 1 * time.Hour
+)
+
+// SelfCheckLoop turns LogoutSelfCheck from a one-shot RPC that nothing
+// calls into a real revocation-propagation mechanism: it runs the check on
+// a jittered timer, immediately on reconnect/resume-from-sleep, and
+// whenever it's poked out-of-band (a gregor push, or an API call that
+// smells like a revoked-device 403).
+type SelfCheckLoop struct {
+	Contextified
+	trigger chan struct{}
+	stopCh  chan struct{}
+}
+
+func NewSelfCheckLoop(g *GlobalContext) *SelfCheckLoop {
+	return &SelfCheckLoop{
+		Contextified: NewContextified(g),
+		// Buffered so a poke from an API-handler goroutine never blocks on
+		// the loop being busy mid-check.
+		trigger: make(chan struct{}, 1),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Poke schedules an immediate self-check, coalescing with any already-
+// pending poke. Call this from the connectivity monitor on reconnect, from
+// the sleep-notifier on resume, from API response handling when a 403
+// looks like a revoked-device error, and from the gregor push handler.
+func (s *SelfCheckLoop) Poke() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Stop shuts down the loop. Safe to call at most once.
+func (s *SelfCheckLoop) Stop() {
+	close(s.stopCh)
+}
+
+// Run starts the poll loop and blocks until Stop is called. Callers should
+// run it in its own goroutine from GlobalContext startup, after login.
+func (s *SelfCheckLoop) Run(mctx MetaContext) {
+	mctx = mctx.WithLogTag("SELFCHECK")
+	backoff := time.Duration(0)
+
+	for {
+		if !s.G().ActiveDevice.Valid() {
+			mctx.Debug("SelfCheckLoop: no active device, skipping this tick")
+		} else if err := mctx.LogoutSelfCheck(); err != nil {
+			backoff = nextSelfCheckBackoff(backoff)
+			mctx.Debug("SelfCheckLoop: LogoutSelfCheck error: %s, backing off %s", err, backoff)
+		} else {
+			backoff = 0
+		}
+
+		wait := backoff
+		if wait == 0 {
+			wait = jitteredSelfCheckInterval()
+		}
+
+		select {
+		case <-s.stopCh:
+			return
+		case <-s.trigger:
+			// Drain and loop immediately, ignoring the timer.
+		case <-time.After(wait):
+		}
+	}
+}
+
+func jitteredSelfCheckInterval() time.Duration {
+	jitter := 1 + selfCheckJitterFrac*(2*rand.Float64()-1)
+	return time.Duration(float64(selfCheckBaseInterval) * jitter)
+}
+
+func nextSelfCheckBackoff(prev time.Duration) time.Duration {
+	if prev == 0 {
+		return 1 * time.Minute
+	}
+	next := prev * 2
+	if next > selfCheckMaxBackoff {
+		return selfCheckMaxBackoff
+	}
+	return next
+}
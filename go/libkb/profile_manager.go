@@ -0,0 +1,132 @@
+package libkb
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Profile records the per-identity names for one logged-in account: its UPAK
+// cache namespace, its session file name, and its secret-store key. It does
+// not hold a sigchain guard, a config reader or any device or session key
+// material; those stay on GlobalContext. ProfileManager tracks which profiles
+// exist and which is active.
+type Profile struct {
+	Username       NormalizedUsername
+	UPAKNamespace  string
+	SessionFile    string
+	SecretStoreKey string
+
+	// LastActive records the last time this profile was the active one, so
+	// callers can offer a recency-sorted account switcher.
+	LastActive Time
+}
+
+func newProfile(username NormalizedUsername) *Profile {
+	return &Profile{
+		Username:       username,
+		UPAKNamespace:  fmt.Sprintf("upak:%s", username),
+		SessionFile:    fmt.Sprintf("session-%s.json", username),
+		SecretStoreKey: username.String(),
+	}
+}
+
+// ProfileManager tracks the set of accounts that are concurrently "logged
+// in" on this device and owns switching the active one. It does not replace
+// GlobalContext's ActiveDevice or the switchedUsers map, which
+// logoutSecretStore still maintains alongside it.
+type ProfileManager struct {
+	sync.RWMutex
+	g        *GlobalContext
+	profiles map[NormalizedUsername]*Profile
+	active   NormalizedUsername
+}
+
+func NewProfileManager(g *GlobalContext) *ProfileManager {
+	return &ProfileManager{
+		g:        g,
+		profiles: make(map[NormalizedUsername]*Profile),
+	}
+}
+
+// Active returns the Profile for the currently active account, or nil if no
+// account is active.
+func (p *ProfileManager) Active() *Profile {
+	p.RLock()
+	defer p.RUnlock()
+	return p.profiles[p.active]
+}
+
+func (p *ProfileManager) profileFor(username NormalizedUsername) *Profile {
+	if prof, ok := p.profiles[username]; ok {
+		return prof
+	}
+	prof := newProfile(username)
+	p.profiles[username] = prof
+	return prof
+}
+
+// Switch atomically makes `username` the active profile: it reloads the
+// global config (the reload is not scoped to a profile), flushes only the caches owned by the
+// previously-active profile, and notifies listeners via HandleProfileSwitch
+// rather than the heavier HandleLogout/login round-trip. Unlike
+// LogoutUsernameWithOptions, it never touches the secret store or local
+// sigchain guard of the outgoing profile, since that account stays logged in
+// underneath.
+//
+// Switch does not install an ActiveDevice for username -- Profile carries no
+// device or session key material to install from, so there is nothing here
+// to swap to. Until a profile can persist that material, callers that need
+// the target account's device active afterwards must still drive their own
+// re-provisioning/login for it; Switch only updates which profile is
+// bookkept as active.
+func (p *ProfileManager) Switch(mctx MetaContext, username NormalizedUsername) (err error) {
+	mctx = mctx.WithLogTag("PROFILE")
+	defer mctx.Trace(fmt.Sprintf("ProfileManager#Switch(%s)", username), func() error { return err })()
+
+	p.Lock()
+	defer p.Unlock()
+
+	previous := p.active
+	if previous.Eq(username) {
+		mctx.Debug("ProfileManager#Switch: %s is already active", username)
+		return nil
+	}
+
+	next := p.profileFor(username)
+
+	if err := mctx.G().ConfigReload(); err != nil {
+		mctx.Debug("ProfileManager#Switch: ConfigReload error: %s", err)
+	}
+
+	// Only flush the caches scoped to the profile we're leaving; the
+	// incoming profile's caches (if any remain warm from a previous
+	// session) are left intact so the switch stays fast.
+	if prev, ok := p.profiles[previous]; ok {
+		mctx.G().FlushCachesForUsername(prev.Username)
+	}
+
+	next.LastActive = mctx.G().Clock().Now()
+	p.active = username
+
+	mctx.G().NotifyRouter.HandleProfileSwitch(mctx.Ctx(), previous.String(), username.String())
+
+	return nil
+}
+
+// DeleteProfile removes `username` from the set of concurrently-logged-in
+// accounts and, if it was active, clears the active profile. It does not run
+// the logout hooks, cache flush, or secret-store clear itself -- by the time
+// LogoutUsernameWithOptions calls this at its end, that sequence has already run scoped to username; this just drops the
+// bookkeeping entry that would otherwise make the account look logged in.
+func (p *ProfileManager) DeleteProfile(mctx MetaContext, username NormalizedUsername, options LogoutOptions) (err error) {
+	p.Lock()
+	wasActive := p.active.Eq(username)
+	delete(p.profiles, username)
+	if wasActive {
+		p.active = NormalizedUsername("")
+	}
+	p.Unlock()
+
+	mctx.Debug("ProfileManager#DeleteProfile: removed profile %s (wasActive=%v)", username, wasActive)
+	return nil
+}
@@ -0,0 +1,85 @@
+package libkb
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SecretStoreBackendName names a SecretStoreBackend in a
+// SecretStoreBackendRegistry, so headless Linux daemons, CI, and enterprise
+// HSM deployments can each plug in a backend. It is separate from
+// KeychainMode: logout.go clears the secret store only when the device's
+// KeychainMode is KeychainModeOS, and then through the registry's active
+// backend if one is selected.
+type SecretStoreBackendName string
+
+const (
+	SecretStoreBackendNameOS        SecretStoreBackendName = "os-keychain"
+	SecretStoreBackendNameLibSecret SecretStoreBackendName = "libsecret"
+	SecretStoreBackendNameFile      SecretStoreBackendName = "encrypted-file"
+	SecretStoreBackendNameHSM       SecretStoreBackendName = "pkcs11-hsm"
+	SecretStoreBackendNameNone      SecretStoreBackendName = "none"
+)
+
+// SecretStoreBackend is the interface a pluggable secret-store
+// implementation must satisfy. It supersedes the single g.secretStore
+// SecretStore interface wherever a caller also needs to reason about
+// whether clearing the secret would be safe (e.g. a non-exportable HSM
+// key).
+type SecretStoreBackend interface {
+	Name() SecretStoreBackendName
+	ClearSecret(mctx MetaContext, username NormalizedUsername) error
+	// CanEraseWithoutDataLoss reports whether clearing this backend's
+	// secret for `username` is safe. Backends whose key material cannot be
+	// re-derived or re-exported (e.g. a non-exportable HSM key) should
+	// return false so CanLogout can refuse the logout.
+	CanEraseWithoutDataLoss(mctx MetaContext, username NormalizedUsername) (bool, string)
+}
+
+// SecretStoreBackendRegistry holds the named backends available on this
+// platform/build. At most one is selected as the active backend at a time,
+// through Select; none is selected until Select is called. The registry does
+// not read the selection from env or config, and it is not per profile.
+type SecretStoreBackendRegistry struct {
+	sync.RWMutex
+	backends map[SecretStoreBackendName]SecretStoreBackend
+	selected SecretStoreBackendName
+}
+
+func NewSecretStoreBackendRegistry() *SecretStoreBackendRegistry {
+	return &SecretStoreBackendRegistry{
+		backends: make(map[SecretStoreBackendName]SecretStoreBackend),
+	}
+}
+
+// Register adds a backend implementation under its own name. Call during
+// platform-specific service init (e.g. an os_darwin.go/os_linux.go
+// equivalent) — last registration for a given name wins, matching how other
+// registries in this package behave.
+func (r *SecretStoreBackendRegistry) Register(backend SecretStoreBackend) {
+	r.Lock()
+	defer r.Unlock()
+	r.backends[backend.Name()] = backend
+}
+
+// Select sets which registered backend the registry hands back from
+// Active(). Returns an error if the backend was never registered, so a
+// misconfigured profile fails loudly instead of silently falling back to
+// nothing.
+func (r *SecretStoreBackendRegistry) Select(name SecretStoreBackendName) error {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.backends[name]; !ok {
+		return fmt.Errorf("secret store backend %q is not registered", name)
+	}
+	r.selected = name
+	return nil
+}
+
+// Active returns the currently-selected backend, or nil if none has been
+// selected or registered.
+func (r *SecretStoreBackendRegistry) Active() SecretStoreBackend {
+	r.RLock()
+	defer r.RUnlock()
+	return r.backends[r.selected]
+}
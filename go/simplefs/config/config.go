@@ -0,0 +1,68 @@
+// Package config carries per-request SimpleFS configuration on a
+// context.Context, so timeouts, identify behavior, retry/pacer policy,
+// bandwidth caps, offline/allow-network flags, and debug verbosity don't
+// need a dedicated arg field threaded through every SimpleFS RPC.
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+type ctxKey int
+
+const configKey ctxKey = 0
+
+// ConfigInfo is the mutable, per-request configuration scoped to a single
+// SimpleFS op. Callers fetch it with GetConfig and mutate it in place;
+// there is no need to re-store it on the context after mutation.
+type ConfigInfo struct {
+	// Timeout bounds the op as a whole, separately from any per-RPC
+	// deadline already on the context.
+	Timeout time.Duration
+	// IdentifyBehavior overrides the default KBFS identify behavior for
+	// this op, mirroring the per-path override on KBFSPath.
+	IdentifyBehavior *keybase1.TLFIdentifyBehavior
+	// AllowNetwork, when false, restricts the op to locally-cached data.
+	AllowNetwork bool
+	// BandwidthCapBytesPerSec caps transfer rate for this op; zero means
+	// unlimited.
+	BandwidthCapBytesPerSec int64
+	// MaxRetries bounds the pacer's retry policy for this op; zero means
+	// the pacer's own default.
+	MaxRetries int
+	// DebugVerbosity raises log verbosity for just this op's lifetime,
+	// without touching the process-wide log level.
+	DebugVerbosity int
+	// TraceParent and TraceState carry a W3C trace context into the RPC
+	// layer for this op, the same way the other fields here avoid adding
+	// a dedicated arg to every SimpleFS RPC. See package trace for the
+	// span-wrapping helpers that populate these.
+	TraceParent string
+	TraceState  string
+}
+
+func defaultConfigInfo() *ConfigInfo {
+	return &ConfigInfo{AllowNetwork: true}
+}
+
+// AddConfig attaches a fresh ConfigInfo to ctx and returns both the new
+// context and the ConfigInfo, so the caller can mutate it before passing
+// the context on to the SimpleFS RPC.
+func AddConfig(ctx context.Context) (context.Context, *ConfigInfo) {
+	info := defaultConfigInfo()
+	return context.WithValue(ctx, configKey, info), info
+}
+
+// GetConfig returns the ConfigInfo attached to ctx, or a default one if
+// none was attached. Handlers registered in SimpleFSProtocol should call
+// this once at the top of the handler and read from it instead of adding
+// new arg fields for per-request behavior.
+func GetConfig(ctx context.Context) *ConfigInfo {
+	if info, ok := ctx.Value(configKey).(*ConfigInfo); ok {
+		return info
+	}
+	return defaultConfigInfo()
+}
@@ -0,0 +1,149 @@
+package fakefs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+func writeFile(t *testing.T, b *Backend, ctx context.Context, path keybase1.Path, content []byte) {
+	t.Helper()
+	o, _ := b.SimpleFSMakeOpid(ctx)
+	if err := b.SimpleFSOpen(ctx, keybase1.SimpleFSOpenArg{OpID: o, Dest: path, Flags: keybase1.OpenFlags_WRITE}); err != nil {
+		t.Fatalf("SimpleFSOpen: %s", err)
+	}
+	if err := b.SimpleFSWrite(ctx, keybase1.SimpleFSWriteArg{OpID: o, Content: content}); err != nil {
+		t.Fatalf("SimpleFSWrite: %s", err)
+	}
+	if err := b.SimpleFSClose(ctx, o); err != nil {
+		t.Fatalf("SimpleFSClose: %s", err)
+	}
+}
+
+func TestBackendWriteThenReadRoundTrip(t *testing.T) {
+	b := NewBackend(Options{})
+	ctx := context.Background()
+	path := keybase1.NewPathWithLocal("/a/b/file")
+	writeFile(t, b, ctx, path, []byte("payload"))
+
+	o, _ := b.SimpleFSMakeOpid(ctx)
+	if err := b.SimpleFSOpen(ctx, keybase1.SimpleFSOpenArg{OpID: o, Dest: path, Flags: keybase1.OpenFlags_EXISTING}); err != nil {
+		t.Fatalf("SimpleFSOpen(EXISTING): %s", err)
+	}
+	content, err := b.SimpleFSRead(ctx, keybase1.SimpleFSReadArg{OpID: o, Offset: 0, Size: 100})
+	if err != nil {
+		t.Fatalf("SimpleFSRead: %s", err)
+	}
+	if string(content.Data) != "payload" {
+		t.Fatalf("got %q, want %q", content.Data, "payload")
+	}
+}
+
+func TestBackendOpenExistingAgainstMissingPathErrors(t *testing.T) {
+	b := NewBackend(Options{})
+	ctx := context.Background()
+	o, _ := b.SimpleFSMakeOpid(ctx)
+	err := b.SimpleFSOpen(ctx, keybase1.SimpleFSOpenArg{
+		OpID:  o,
+		Dest:  keybase1.NewPathWithLocal("/does/not/exist"),
+		Flags: keybase1.OpenFlags_EXISTING,
+	})
+	if err == nil {
+		t.Fatal("expected an error opening a nonexistent path with OpenFlags_EXISTING")
+	}
+	if n := b.lookup("/does/not/exist"); n != nil {
+		t.Fatal("OpenFlags_EXISTING against a missing path must not create it")
+	}
+}
+
+func TestBackendHashOffsetPastEOFDoesNotPanic(t *testing.T) {
+	b := NewBackend(Options{})
+	ctx := context.Background()
+	path := keybase1.NewPathWithLocal("/f")
+	writeFile(t, b, ctx, path, []byte("short"))
+
+	o, _ := b.SimpleFSMakeOpid(ctx)
+	if err := b.SimpleFSHash(ctx, keybase1.SimpleFSHashArg{
+		OpID:   o,
+		Path:   path,
+		Offset: 1000,
+		Size:   10,
+		Type:   keybase1.HashType_SHA256,
+	}); err != nil {
+		t.Fatalf("SimpleFSHash: %s", err)
+	}
+	res, err := b.SimpleFSReadHash(ctx, o)
+	if err != nil {
+		t.Fatalf("SimpleFSReadHash: %s", err)
+	}
+	if res.BytesHashed != 0 {
+		t.Fatalf("BytesHashed = %d, want 0 for an offset past EOF", res.BytesHashed)
+	}
+}
+
+func TestBackendListFiltersHiddenEntries(t *testing.T) {
+	b := NewBackend(Options{})
+	ctx := context.Background()
+	writeFile(t, b, ctx, keybase1.NewPathWithLocal("/dir/visible"), []byte("x"))
+	writeFile(t, b, ctx, keybase1.NewPathWithLocal("/dir/.hidden"), []byte("x"))
+
+	o, _ := b.SimpleFSMakeOpid(ctx)
+	if err := b.SimpleFSList(ctx, keybase1.SimpleFSListArg{
+		OpID:   o,
+		Path:   keybase1.NewPathWithLocal("/dir"),
+		Filter: keybase1.ListFilter_FILTER_ALL_HIDDEN,
+	}); err != nil {
+		t.Fatalf("SimpleFSList: %s", err)
+	}
+	res, err := b.SimpleFSReadList(ctx, o)
+	if err != nil {
+		t.Fatalf("SimpleFSReadList: %s", err)
+	}
+	if len(res.Entries) != 1 || res.Entries[0].Name != "visible" {
+		t.Fatalf("got %v, want just \"visible\"", res.Entries)
+	}
+}
+
+func TestBackendCopySkipsWhenHashMatches(t *testing.T) {
+	b := NewBackend(Options{})
+	ctx := context.Background()
+	src := keybase1.NewPathWithLocal("/src")
+	dest := keybase1.NewPathWithLocal("/dest")
+	writeFile(t, b, ctx, src, []byte("same"))
+	writeFile(t, b, ctx, dest, []byte("same"))
+
+	sha256 := keybase1.HashType_SHA256
+	before := b.lookup("/dest")
+	o, _ := b.SimpleFSMakeOpid(ctx)
+	if err := b.SimpleFSCopy(ctx, keybase1.SimpleFSCopyArg{
+		OpID:              o,
+		Src:               src,
+		Dest:              dest,
+		SkipIfHashMatches: &sha256,
+	}); err != nil {
+		t.Fatalf("SimpleFSCopy: %s", err)
+	}
+	// The contents are equal either way, so check node identity: a copy
+	// that ran would have put a clone of src in dest's place.
+	if b.lookup("/dest") != before {
+		t.Fatal("dest node was replaced although src and dest hashes match")
+	}
+
+	// A src whose hash differs from dest's must NOT be skipped.
+	diffSrc := keybase1.NewPathWithLocal("/diffsrc")
+	writeFile(t, b, ctx, diffSrc, []byte("different"))
+	o2, _ := b.SimpleFSMakeOpid(ctx)
+	if err := b.SimpleFSCopy(ctx, keybase1.SimpleFSCopyArg{
+		OpID:              o2,
+		Src:               diffSrc,
+		Dest:              dest,
+		SkipIfHashMatches: &sha256,
+	}); err != nil {
+		t.Fatalf("SimpleFSCopy: %s", err)
+	}
+	n := b.lookup("/dest")
+	if string(n.data) != "different" {
+		t.Fatalf("dest = %q, want %q (copy with a mismatched hash must not be skipped)", n.data, "different")
+	}
+}
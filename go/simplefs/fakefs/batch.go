@@ -0,0 +1,166 @@
+package fakefs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// SimpleFSSyncDelta performs the copy in one shot -- the fake has no
+// network to save bytes over, so BytesMatched/BytesSent just report the
+// whole-file result as fully "sent", which is enough for a caller
+// checking the op completed and progress fields are populated.
+func (b *Backend) SimpleFSSyncDelta(ctx context.Context, arg keybase1.SimpleFSSyncDeltaArg) error {
+	if err := b.checkInjectedError("simpleFSSyncDelta"); err != nil {
+		return err
+	}
+	st := b.newOp(arg.OpID, keybase1.AsyncOps_SYNC_DELTA)
+	srcKey, err := pathKey(arg.Src)
+	if err != nil {
+		b.finishOp(arg.OpID, nil, err)
+		return nil
+	}
+	destKey, err := pathKey(arg.Dest)
+	if err != nil {
+		b.finishOp(arg.OpID, nil, err)
+		return nil
+	}
+	err = b.copyOne(srcKey, destKey)
+	if err == nil {
+		b.mu.Lock()
+		n := b.lookup(destKey)
+		st.progress.BytesSent = int64(len(n.data))
+		b.mu.Unlock()
+	}
+	b.finishOp(arg.OpID, nil, err)
+	return nil
+}
+
+// SimpleFSBatch runs each op in arg.Ops in order against the fake,
+// stopping early if arg.StopOnError is set and one fails. Only the
+// copy/move/remove/makeOpid/wait/close/check variants are dispatched to
+// real behavior; anything else reports an error in its OpResult rather
+// than silently no-opping, since a caller's batch-reducing fuzz harness
+// should be able to tell the difference.
+//
+// A step whose own Args carries the zero-value OpID (see MakeOpidArgs)
+// is rewritten in place to use the OpID minted by the most recent
+// preceding MAKE_OPID step, so a MakeOpid -> Copy -> Wait -> Check ->
+// Close chain never round-trips through the caller.
+func (b *Backend) SimpleFSBatch(ctx context.Context, arg keybase1.SimpleFSBatchArg) error {
+	if err := b.checkInjectedError("simpleFSBatch"); err != nil {
+		return err
+	}
+	st := b.newOp(arg.OpID, keybase1.AsyncOps_BATCH)
+	results := make([]keybase1.OpResult, len(arg.Ops))
+	var lastMinted keybase1.OpID
+	for i, op := range arg.Ops {
+		resolveBatchOpID(op, lastMinted)
+		res, err := b.runBatchOp(ctx, op)
+		if res.OpID != nil {
+			lastMinted = *res.OpID
+		}
+		if err != nil {
+			msg := err.Error()
+			res.Error = &msg
+			results[i] = res
+			if arg.StopOnError {
+				for j := i + 1; j < len(arg.Ops); j++ {
+					skipped := "fakefs: skipped after earlier batch error"
+					results[j].Error = &skipped
+				}
+				break
+			}
+			continue
+		}
+		results[i] = res
+	}
+	st.result = keybase1.SimpleFSBatchResult{Results: results}
+	return nil
+}
+
+// resolveBatchOpID substitutes the zero-value OpID in op's Args (if any)
+// with lastMinted, per the MAKE_OPID chaining convention documented on
+// MakeOpidArgs.
+func resolveBatchOpID(op keybase1.OpDescription, lastMinted keybase1.OpID) {
+	var zero keybase1.OpID
+	switch {
+	case op.Copy__ != nil && op.Copy__.OpID == zero:
+		op.Copy__.OpID = lastMinted
+	case op.Move__ != nil && op.Move__.OpID == zero:
+		op.Move__.OpID = lastMinted
+	case op.Remove__ != nil && op.Remove__.OpID == zero:
+		op.Remove__.OpID = lastMinted
+	case op.Wait__ != nil && op.Wait__.OpID == zero:
+		op.Wait__.OpID = lastMinted
+	case op.Close__ != nil && op.Close__.OpID == zero:
+		op.Close__.OpID = lastMinted
+	case op.Check__ != nil && op.Check__.OpID == zero:
+		op.Check__.OpID = lastMinted
+	}
+}
+
+func (b *Backend) runBatchOp(ctx context.Context, op keybase1.OpDescription) (keybase1.OpResult, error) {
+	switch {
+	case op.Copy__ != nil:
+		// Registered here, the same way SimpleFSMove registers its own
+		// OpID, so a chained Wait__/Check__/Close__ step later in the
+		// batch (or resolveBatchOpID's MAKE_OPID chaining) finds it
+		// instead of failing with "unknown OpID".
+		b.newOp(op.Copy__.OpID, keybase1.AsyncOps_COPY)
+		srcKey, err := pathKey(op.Copy__.Src)
+		if err != nil {
+			b.finishOp(op.Copy__.OpID, nil, err)
+			return keybase1.OpResult{}, err
+		}
+		destKey, err := pathKey(op.Copy__.Dest)
+		if err != nil {
+			b.finishOp(op.Copy__.OpID, nil, err)
+			return keybase1.OpResult{}, err
+		}
+		err = b.copyOne(srcKey, destKey)
+		b.finishOp(op.Copy__.OpID, nil, err)
+		return keybase1.OpResult{}, err
+	case op.Move__ != nil:
+		return keybase1.OpResult{}, b.SimpleFSMove(ctx, keybase1.SimpleFSMoveArg{OpID: op.Move__.OpID, Src: op.Move__.Src, Dest: op.Move__.Dest})
+	case op.Remove__ != nil:
+		// SimpleFSRemove itself doesn't register op.Remove__.OpID, so
+		// register/finish it here for the same reason as Copy__ above.
+		b.newOp(op.Remove__.OpID, keybase1.AsyncOps_REMOVE)
+		err := b.SimpleFSRemove(ctx, keybase1.SimpleFSRemoveArg{Path: op.Remove__.Path, Recursive: op.Remove__.Recursive})
+		b.finishOp(op.Remove__.OpID, nil, err)
+		return keybase1.OpResult{}, err
+	case op.MakeOpid__ != nil:
+		opID, err := b.SimpleFSMakeOpid(ctx)
+		if err != nil {
+			return keybase1.OpResult{}, err
+		}
+		return keybase1.OpResult{OpID: &opID}, nil
+	case op.Wait__ != nil:
+		return keybase1.OpResult{}, b.SimpleFSWait(ctx, op.Wait__.OpID)
+	case op.Close__ != nil:
+		return keybase1.OpResult{}, b.SimpleFSClose(ctx, op.Close__.OpID)
+	case op.Check__ != nil:
+		progress, err := b.SimpleFSCheck(ctx, op.Check__.OpID)
+		if err != nil {
+			return keybase1.OpResult{}, err
+		}
+		return keybase1.OpResult{Progress: &progress}, nil
+	default:
+		return keybase1.OpResult{}, fmt.Errorf("fakefs: batch op %v not supported by the fake", op.AsyncOp__)
+	}
+}
+
+// SimpleFSReadBatch returns the result buffered by SimpleFSBatch.
+func (b *Backend) SimpleFSReadBatch(ctx context.Context, opID keybase1.OpID) (keybase1.SimpleFSBatchResult, error) {
+	st, err := b.getOp(opID)
+	if err != nil {
+		return keybase1.SimpleFSBatchResult{}, err
+	}
+	if st.err != nil {
+		return keybase1.SimpleFSBatchResult{}, st.err
+	}
+	res, _ := st.result.(keybase1.SimpleFSBatchResult)
+	return res, nil
+}
@@ -0,0 +1,87 @@
+package fakefs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+func opID(b byte) keybase1.OpID {
+	var id keybase1.OpID
+	id[0] = b
+	return id
+}
+
+func TestResolveBatchOpIDFillsZeroValue(t *testing.T) {
+	minted := opID(1)
+	op := keybase1.OpDescription{Copy__: &keybase1.CopyArgs{}}
+	resolveBatchOpID(op, minted)
+	if op.Copy__.OpID != minted {
+		t.Fatalf("got %x, want %x", op.Copy__.OpID, minted)
+	}
+}
+
+func TestResolveBatchOpIDLeavesExplicitOpIDAlone(t *testing.T) {
+	minted := opID(1)
+	explicit := opID(2)
+	op := keybase1.OpDescription{Remove__: &keybase1.RemoveArgs{OpID: explicit}}
+	resolveBatchOpID(op, minted)
+	if op.Remove__.OpID != explicit {
+		t.Fatalf("got %x, want %x (explicit OpID should not be overwritten)", op.Remove__.OpID, explicit)
+	}
+}
+
+func TestResolveBatchOpIDIgnoresUnrelatedVariant(t *testing.T) {
+	minted := opID(1)
+	op := keybase1.OpDescription{MakeOpid__: &keybase1.MakeOpidArgs{}}
+	resolveBatchOpID(op, minted)
+}
+
+func TestBackendBatchCopyThenWaitThenClose(t *testing.T) {
+	b := NewBackend(Options{})
+	ctx := context.Background()
+
+	srcPath := keybase1.NewPathWithLocal("/src")
+	destPath := keybase1.NewPathWithLocal("/dest")
+
+	openOp, _ := b.SimpleFSMakeOpid(ctx)
+	if err := b.SimpleFSOpen(ctx, keybase1.SimpleFSOpenArg{OpID: openOp, Dest: srcPath, Flags: keybase1.OpenFlags_WRITE}); err != nil {
+		t.Fatalf("SimpleFSOpen: %s", err)
+	}
+	if err := b.SimpleFSWrite(ctx, keybase1.SimpleFSWriteArg{OpID: openOp, Content: []byte("hello")}); err != nil {
+		t.Fatalf("SimpleFSWrite: %s", err)
+	}
+
+	batchID := opID(10)
+	err := b.SimpleFSBatch(ctx, keybase1.SimpleFSBatchArg{
+		OpID: batchID,
+		Ops: []keybase1.OpDescription{
+			{AsyncOp__: keybase1.AsyncOps_MAKE_OPID, MakeOpid__: &keybase1.MakeOpidArgs{}},
+			{AsyncOp__: keybase1.AsyncOps_COPY, Copy__: &keybase1.CopyArgs{Src: srcPath, Dest: destPath}},
+			{AsyncOp__: keybase1.AsyncOps_WAIT, Wait__: &keybase1.WaitArgs{}},
+			{AsyncOp__: keybase1.AsyncOps_CLOSE, Close__: &keybase1.CloseArgs{}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SimpleFSBatch: %s", err)
+	}
+
+	res, err := b.SimpleFSReadBatch(ctx, batchID)
+	if err != nil {
+		t.Fatalf("SimpleFSReadBatch: %s", err)
+	}
+	for i, r := range res.Results {
+		if r.Error != nil {
+			t.Fatalf("batch step %d failed: %s", i, *r.Error)
+		}
+	}
+
+	stat, err := b.SimpleFSStat(ctx, keybase1.SimpleFSStatArg{Path: destPath})
+	if err != nil {
+		t.Fatalf("SimpleFSStat(dest): %s", err)
+	}
+	if stat.Size != len("hello") {
+		t.Fatalf("dest size = %d, want %d", stat.Size, len("hello"))
+	}
+}
@@ -0,0 +1,49 @@
+package fakefs
+
+import (
+	"os"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// NewClient returns a keybase1.SimpleFSInterface backed by a fresh, empty
+// Backend configured with opts. Callers that want to seed or inspect the
+// fake's state afterward (Snapshot/Rollback, Emit) should hold onto the
+// *Backend from NewBackend directly instead.
+func NewClient(opts Options) keybase1.SimpleFSInterface {
+	return NewBackend(opts)
+}
+
+// fakefsEnvVar, set to "1", switches every client built via
+// MaybeWrapClient onto the in-memory fake regardless of path, for test
+// suites that want to run entirely hermetic without a KBFS daemon.
+const fakefsEnvVar = "SIMPLEFS_FAKEFS"
+
+// fakefsPrefix marks an individual path as fake-backed even when the
+// real client is otherwise in use, so a single test can reach the fake
+// without flipping the whole process onto it.
+const fakefsPrefix = "fakefs://"
+
+// MaybeWrapClient returns real unchanged, unless SIMPLEFS_FAKEFS=1 is set
+// in the environment, in which case it returns a fresh fake client built
+// with opts instead. Call this once at client-construction time; for
+// per-path fakefs:// routing within an otherwise-real client, see
+// StripFakefsPrefix.
+func MaybeWrapClient(real keybase1.SimpleFSInterface, opts Options) keybase1.SimpleFSInterface {
+	if os.Getenv(fakefsEnvVar) != "1" {
+		return real
+	}
+	return NewClient(opts)
+}
+
+// StripFakefsPrefix removes path's "fakefs://" prefix if present, and
+// reports whether it was there, so a caller that routes individual
+// LOCAL paths to the fake (rather than switching the whole client via
+// SIMPLEFS_FAKEFS=1) knows which backend a given call should address.
+func StripFakefsPrefix(path string) (stripped string, ok bool) {
+	if strings.HasPrefix(path, fakefsPrefix) {
+		return strings.TrimPrefix(path, fakefsPrefix), true
+	}
+	return path, false
+}
@@ -0,0 +1,582 @@
+package fakefs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// SimpleFSOpen creates or opens arg.Dest and associates it with arg.OpID
+// for the subsequent Read/Write/SetStat calls that address it.
+func (b *Backend) SimpleFSOpen(ctx context.Context, arg keybase1.SimpleFSOpenArg) error {
+	if err := b.checkInjectedError("simpleFSOpen"); err != nil {
+		return err
+	}
+	key, err := pathKey(arg.Dest)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if arg.Flags&keybase1.OpenFlags_DIRECTORY != 0 {
+		if err := b.mkdirAllLocked(key); err != nil {
+			return err
+		}
+	} else {
+		existing := b.lookup(key)
+		switch {
+		case existing == nil && arg.Flags&keybase1.OpenFlags_EXISTING != 0:
+			return fmt.Errorf("fakefs: %q does not exist", key)
+		case existing == nil:
+			parent, leaf, err := b.mkdirParents(key)
+			if err != nil {
+				return err
+			}
+			parent.children[leaf] = &node{mtime: nowTime()}
+		case arg.Flags&keybase1.OpenFlags_REPLACE != 0:
+			b.usedBytes -= int64(len(existing.data))
+			existing.data = nil
+			existing.mtime = nowTime()
+		}
+	}
+
+	b.ops[arg.OpID] = &opState{openPath: key}
+	return nil
+}
+
+func (b *Backend) mkdirAllLocked(key string) error {
+	if n := b.lookup(key); n != nil {
+		if !n.isDir {
+			return fmt.Errorf("fakefs: %q exists and is not a directory", key)
+		}
+		return nil
+	}
+	parent, leaf, err := b.mkdirParents(key)
+	if err != nil {
+		return err
+	}
+	parent.children[leaf] = newDir()
+	return nil
+}
+
+// SimpleFSSetStat updates arg.Dest's mtime. It does not set or clear an
+// executable bit: arg.Flag is not stored.
+func (b *Backend) SimpleFSSetStat(ctx context.Context, arg keybase1.SimpleFSSetStatArg) error {
+	key, err := pathKey(arg.Dest)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(key)
+	if n == nil {
+		return fmt.Errorf("fakefs: no such path %q", key)
+	}
+	// The fake tracks DirentType only as file/dir/sym, so an EXEC
+	// arg.Flag is dropped and later Stat/List calls never report
+	// DirentType_EXEC.
+	n.mtime = nowTime()
+	return nil
+}
+
+// SimpleFSRead returns up to arg.Size bytes of the file opened under
+// arg.OpID, starting at arg.Offset.
+func (b *Backend) SimpleFSRead(ctx context.Context, arg keybase1.SimpleFSReadArg) (keybase1.FileContent, error) {
+	if err := b.checkInjectedError("simpleFSRead"); err != nil {
+		return keybase1.FileContent{}, err
+	}
+	st, err := b.getOp(arg.OpID)
+	if err != nil {
+		return keybase1.FileContent{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(st.openPath)
+	if n == nil {
+		return keybase1.FileContent{}, fmt.Errorf("fakefs: %q no longer exists", st.openPath)
+	}
+	if arg.Offset >= int64(len(n.data)) {
+		return keybase1.FileContent{}, nil
+	}
+	end := len(n.data)
+	if arg.Size > 0 && arg.Offset+int64(arg.Size) < int64(end) {
+		end = int(arg.Offset) + arg.Size
+	}
+	return keybase1.FileContent{Data: append([]byte(nil), n.data[arg.Offset:end]...)}, nil
+}
+
+// SimpleFSWrite writes arg.Content into the file opened under arg.OpID at
+// arg.Offset, overwriting bytes already there and growing the file as
+// needed, and records a revision of the prior content.
+func (b *Backend) SimpleFSWrite(ctx context.Context, arg keybase1.SimpleFSWriteArg) error {
+	if err := b.checkInjectedError("simpleFSWrite"); err != nil {
+		return err
+	}
+	st, err := b.getOp(arg.OpID)
+	if err != nil {
+		return err
+	}
+	return b.writeAt(st.openPath, arg.Offset, arg.Content)
+}
+
+// SimpleFSWriteAt writes one chunk of arg.OpID's open file at arg.Offset,
+// the same as SimpleFSWrite. The fake has no out-of-process manifest to
+// persist, so calls may still arrive out of order and in parallel -- they
+// just land directly in the node's backing buffer.
+func (b *Backend) SimpleFSWriteAt(ctx context.Context, arg keybase1.SimpleFSWriteAtArg) error {
+	if err := b.checkInjectedError("simpleFSWriteAt"); err != nil {
+		return err
+	}
+	st, err := b.getOp(arg.OpID)
+	if err != nil {
+		return err
+	}
+	return b.writeAt(st.openPath, arg.Offset, arg.Content)
+}
+
+// SimpleFSCommitMultipart finishes a multipart upload. The fake has
+// already applied every SimpleFSWriteAt chunk directly, so there's
+// nothing left to assemble; this just validates the OpID is still open.
+func (b *Backend) SimpleFSCommitMultipart(ctx context.Context, arg keybase1.SimpleFSCommitMultipartArg) error {
+	if err := b.checkInjectedError("simpleFSCommitMultipart"); err != nil {
+		return err
+	}
+	_, err := b.getOp(arg.OpID)
+	return err
+}
+
+// SimpleFSResumeUpload opens a fresh OpID for arg.Path. The fake doesn't
+// persist an upload manifest across restarts, so it always reports an
+// empty ResumeState -- callers resuming against the fake should expect to
+// resend every part.
+func (b *Backend) SimpleFSResumeUpload(ctx context.Context, arg keybase1.SimpleFSResumeUploadArg) (keybase1.SimpleFSResumeUploadResult, error) {
+	if err := b.checkInjectedError("simpleFSResumeUpload"); err != nil {
+		return keybase1.SimpleFSResumeUploadResult{}, err
+	}
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return keybase1.SimpleFSResumeUploadResult{}, err
+	}
+	opID, err := b.SimpleFSMakeOpid(ctx)
+	if err != nil {
+		return keybase1.SimpleFSResumeUploadResult{}, err
+	}
+	st := b.newOp(opID, keybase1.AsyncOps_WRITE)
+	st.openPath = key
+	return keybase1.SimpleFSResumeUploadResult{
+		OpID:  opID,
+		State: keybase1.ResumeState{UploadID: arg.UploadID},
+	}, nil
+}
+
+func (b *Backend) writeAt(key string, offset int64, content []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(key)
+	if n == nil {
+		return fmt.Errorf("fakefs: %q no longer exists", key)
+	}
+	if extra := int64(len(content)) - (int64(len(n.data)) - offset); extra > 0 {
+		if err := b.reserve(extra); err != nil {
+			return err
+		}
+		b.usedBytes += extra
+	}
+	n.recordRevision()
+	needed := int(offset) + len(content)
+	if needed > len(n.data) {
+		grown := make([]byte, needed)
+		copy(grown, n.data)
+		n.data = grown
+	}
+	copy(n.data[offset:], content)
+	n.mtime = nowTime()
+	return nil
+}
+
+func (n *node) recordRevision() {
+	n.revisions = append(n.revisions, revision{
+		rev:  keybase1.KBFSRevision(len(n.revisions) + 1),
+		data: append([]byte(nil), n.data...),
+		time: n.mtime,
+	})
+}
+
+// SimpleFSReadStream and SimpleFSWriteStream aren't modeled as true
+// out-of-band chunk pushes in the fake -- tests exercising the RPC
+// surface don't need real backpressure, just a working Read/Write they
+// can call synchronously. SimpleFSReadStream and SimpleFSWriteStream only
+// check that arg.OpID is open; ReadStream pushes no chunks, and each
+// SimpleFSWriteStreamChunk is written with the same writeAt SimpleFSWrite
+// uses.
+func (b *Backend) SimpleFSReadStream(ctx context.Context, arg keybase1.SimpleFSReadStreamArg) error {
+	_, err := b.getOp(arg.OpID)
+	return err
+}
+
+func (b *Backend) SimpleFSWriteStream(ctx context.Context, arg keybase1.SimpleFSWriteStreamArg) error {
+	_, err := b.getOp(arg.OpID)
+	return err
+}
+
+func (b *Backend) SimpleFSWriteStreamChunk(ctx context.Context, arg keybase1.SimpleFSWriteStreamChunkArg) error {
+	st, err := b.getOp(arg.OpID)
+	if err != nil {
+		return err
+	}
+	if len(arg.Chunk.Data) == 0 {
+		return nil
+	}
+	return b.writeAt(st.openPath, arg.Chunk.Offset, arg.Chunk.Data)
+}
+
+// SimpleFSRemove deletes the entry at arg.Path.
+func (b *Backend) SimpleFSRemove(ctx context.Context, arg keybase1.SimpleFSRemoveArg) error {
+	if err := b.checkInjectedError("simpleFSRemove"); err != nil {
+		return err
+	}
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(key)
+	if n == nil {
+		return nil
+	}
+	if n.isDir && len(n.children) > 0 && !arg.Recursive {
+		return fmt.Errorf("fakefs: %q is not empty", key)
+	}
+	b.usedBytes -= sizeOf(n)
+	parent, leaf, err := b.mkdirParents(key)
+	if err != nil {
+		return err
+	}
+	delete(parent.children, leaf)
+	return nil
+}
+
+// SimpleFSStat returns info about arg.Path.
+func (b *Backend) SimpleFSStat(ctx context.Context, arg keybase1.SimpleFSStatArg) (keybase1.Dirent, error) {
+	if err := b.checkInjectedError("simpleFSStat"); err != nil {
+		return keybase1.Dirent{}, err
+	}
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return keybase1.Dirent{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(key)
+	if n == nil {
+		return keybase1.Dirent{}, fmt.Errorf("fakefs: no such path %q", key)
+	}
+	return b.direntFor(lastComponent(key), n), nil
+}
+
+func (b *Backend) copyOne(srcKey, destKey string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	src := b.lookup(srcKey)
+	if src == nil {
+		return fmt.Errorf("fakefs: no such path %q", srcKey)
+	}
+	if err := b.reserve(sizeOf(src)); err != nil {
+		return err
+	}
+	parent, leaf, err := b.mkdirParents(destKey)
+	if err != nil {
+		return err
+	}
+	var replaced int64
+	if old := parent.children[leaf]; old != nil {
+		replaced = sizeOf(old)
+	}
+	cp := cloneNode(src)
+	cp.mtime = nowTime()
+	parent.children[leaf] = cp
+	b.usedBytes += sizeOf(cp) - replaced
+	return nil
+}
+
+// SimpleFSCopy copies src to dest, honoring MirrorOpts.CompareBy and
+// SkipIfHashMatches to skip the copy when dest is already up to date.
+func (b *Backend) SimpleFSCopy(ctx context.Context, arg keybase1.SimpleFSCopyArg) error {
+	if err := b.checkInjectedError("simpleFSCopy"); err != nil {
+		return err
+	}
+	srcKey, err := pathKey(arg.Src)
+	if err != nil {
+		return err
+	}
+	destKey, err := pathKey(arg.Dest)
+	if err != nil {
+		return err
+	}
+	if arg.MirrorOpts != nil && b.upToDate(srcKey, destKey, arg.MirrorOpts.CompareBy) {
+		return nil
+	}
+	if arg.SkipIfHashMatches != nil && b.hashesMatch(srcKey, destKey, *arg.SkipIfHashMatches) {
+		return nil
+	}
+	return b.copyOne(srcKey, destKey)
+}
+
+// hashesMatch reports whether srcKey and destKey are both existing,
+// non-directory files whose contents digest equal under hashType, for
+// SkipIfHashMatches.
+func (b *Backend) hashesMatch(srcKey, destKey string, hashType keybase1.HashType) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	src := b.lookup(srcKey)
+	dest := b.lookup(destKey)
+	if src == nil || dest == nil || src.isDir || dest.isDir {
+		return false
+	}
+	return bytes.Equal(hashData(src.data, hashType), hashData(dest.data, hashType))
+}
+
+// SimpleFSSymlink creates a symlink at arg.Link pointing at arg.Target.
+func (b *Backend) SimpleFSSymlink(ctx context.Context, arg keybase1.SimpleFSSymlinkArg) error {
+	key, err := pathKey(arg.Link)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	parent, leaf, err := b.mkdirParents(key)
+	if err != nil {
+		return err
+	}
+	parent.children[leaf] = &node{isSym: true, symlink: arg.Target, mtime: nowTime()}
+	return nil
+}
+
+// copyRecursive copies srcKey to destKey recursively. st is optional
+// (SimpleFSMirror doesn't track a checkpoint); when set, its
+// CheckpointPath is updated to srcKey after each file finishes copying,
+// so SimpleFSResumeOpid has somewhere to pick back up from. skipIfHash,
+// when set, skips each file whose digest already matches dest, the same
+// as SimpleFSCopy's SkipIfHashMatches.
+func (b *Backend) copyRecursive(srcKey, destKey string, opts *keybase1.MirrorOpts, skipIfHash *keybase1.HashType, st *opState) error {
+	b.mu.Lock()
+	src := b.lookup(srcKey)
+	b.mu.Unlock()
+	if src == nil {
+		return fmt.Errorf("fakefs: no such path %q", srcKey)
+	}
+	if !src.isDir {
+		if opts != nil && b.upToDate(srcKey, destKey, opts.CompareBy) {
+			return nil
+		}
+		if skipIfHash != nil && b.hashesMatch(srcKey, destKey, *skipIfHash) {
+			return nil
+		}
+		if err := b.copyOne(srcKey, destKey); err != nil {
+			return err
+		}
+		if st != nil {
+			b.mu.Lock()
+			st.progress.CheckpointPath = srcKey
+			st.progress.FilesWritten++
+			b.mu.Unlock()
+		}
+		return nil
+	}
+
+	b.mu.Lock()
+	names := make([]string, 0, len(src.children))
+	for name := range src.children {
+		names = append(names, name)
+	}
+	b.mu.Unlock()
+	for _, name := range names {
+		if err := b.copyRecursive(srcKey+"/"+name, destKey+"/"+name, opts, skipIfHash, st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SimpleFSCopyRecursive copies src to dest recursively, honoring
+// MirrorOpts and SkipIfHashMatches the same way SimpleFSCopy does
+// per-file. It registers arg.OpID so SimpleFSCheck can report progress,
+// and records a CheckpointPath after each file so an interrupted copy
+// can be resumed via SimpleFSResumeOpid instead of starting over.
+func (b *Backend) SimpleFSCopyRecursive(ctx context.Context, arg keybase1.SimpleFSCopyRecursiveArg) error {
+	if err := b.checkInjectedError("simpleFSCopyRecursive"); err != nil {
+		return err
+	}
+	srcKey, err := pathKey(arg.Src)
+	if err != nil {
+		return err
+	}
+	destKey, err := pathKey(arg.Dest)
+	if err != nil {
+		return err
+	}
+	st := b.newOp(arg.OpID, keybase1.AsyncOps_COPY)
+	b.mu.Lock()
+	if src := b.lookup(srcKey); src != nil {
+		st.progress.CheckpointRevision = int64(len(src.revisions))
+	}
+	b.mu.Unlock()
+	err = b.copyRecursive(srcKey, destKey, arg.MirrorOpts, arg.SkipIfHashMatches, st)
+	b.finishOp(arg.OpID, nil, err)
+	return err
+}
+
+func (b *Backend) upToDate(srcKey, destKey string, compareBy keybase1.CompareBy) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	src := b.lookup(srcKey)
+	dest := b.lookup(destKey)
+	if src == nil || dest == nil || src.isDir != dest.isDir {
+		return false
+	}
+	switch compareBy {
+	case keybase1.CompareBy_MTIME:
+		return dest.mtime >= src.mtime
+	default: // SIZE, CHECKSUM, ETAG all reduce to a content comparison in the fake
+		return len(src.data) == len(dest.data) && string(src.data) == string(dest.data)
+	}
+}
+
+// SimpleFSMirror walks src and dest in parallel and only copies (or, with
+// MirrorOpts.Delete, removes from dest) what's actually out of date --
+// the deterministic behavior the caller needs to fuzz against.
+func (b *Backend) SimpleFSMirror(ctx context.Context, arg keybase1.SimpleFSMirrorArg) error {
+	if err := b.checkInjectedError("simpleFSMirror"); err != nil {
+		return err
+	}
+	srcKey, err := pathKey(arg.Src)
+	if err != nil {
+		return err
+	}
+	destKey, err := pathKey(arg.Dest)
+	if err != nil {
+		return err
+	}
+	if arg.MirrorOpts.DryRun {
+		return nil
+	}
+	if err := b.copyRecursive(srcKey, destKey, &arg.MirrorOpts, nil, nil); err != nil {
+		return err
+	}
+	if arg.MirrorOpts.Delete {
+		b.pruneExtra(srcKey, destKey)
+	}
+	return nil
+}
+
+// pruneExtra removes entries under destKey that have no counterpart
+// under srcKey, for MirrorOpts.Delete.
+func (b *Backend) pruneExtra(srcKey, destKey string) {
+	b.mu.Lock()
+	src := b.lookup(srcKey)
+	dest := b.lookup(destKey)
+	if src == nil || dest == nil || !dest.isDir {
+		b.mu.Unlock()
+		return
+	}
+	var extra []string
+	for name := range dest.children {
+		if _, ok := src.children[name]; !ok {
+			extra = append(extra, name)
+		}
+	}
+	for _, name := range extra {
+		b.usedBytes -= sizeOf(dest.children[name])
+		delete(dest.children, name)
+	}
+	b.mu.Unlock()
+	if src != nil {
+		for name := range src.children {
+			b.pruneExtra(srcKey+"/"+name, destKey+"/"+name)
+		}
+	}
+}
+
+// SimpleFSMove moves src to dest (copy then remove), KBFS-side semantics
+// the fake doesn't need to distinguish from a local move. It registers
+// arg.OpID and records a CheckpointPath the same way SimpleFSCopyRecursive
+// does, so SimpleFSResumeOpid works uniformly across both.
+func (b *Backend) SimpleFSMove(ctx context.Context, arg keybase1.SimpleFSMoveArg) error {
+	srcKey, err := pathKey(arg.Src)
+	if err != nil {
+		return err
+	}
+	destKey, err := pathKey(arg.Dest)
+	if err != nil {
+		return err
+	}
+	st := b.newOp(arg.OpID, keybase1.AsyncOps_MOVE)
+	if err := b.copyOne(srcKey, destKey); err != nil {
+		b.finishOp(arg.OpID, nil, err)
+		return err
+	}
+	err = b.SimpleFSRemove(ctx, keybase1.SimpleFSRemoveArg{Path: arg.Src, Recursive: true})
+	if err == nil {
+		b.mu.Lock()
+		st.progress.CheckpointPath = srcKey
+		st.progress.FilesWritten++
+		b.mu.Unlock()
+	}
+	b.finishOp(arg.OpID, nil, err)
+	return err
+}
+
+// SimpleFSRename renames src to dest in place.
+func (b *Backend) SimpleFSRename(ctx context.Context, arg keybase1.SimpleFSRenameArg) error {
+	return b.SimpleFSMove(ctx, keybase1.SimpleFSMoveArg{Src: arg.Src, Dest: arg.Dest})
+}
+
+// SimpleFSSymlinkCreate begins creating a symlink, tracked by arg.OpID
+// the way other Begin* ops are.
+func (b *Backend) SimpleFSSymlinkCreate(ctx context.Context, arg keybase1.SimpleFSSymlinkCreateArg) error {
+	err := b.SimpleFSSymlink(ctx, keybase1.SimpleFSSymlinkArg{Target: arg.Target, Link: arg.Link})
+	b.newOp(arg.OpID, keybase1.AsyncOps_SYMLINK_CREATE)
+	b.finishOp(arg.OpID, nil, err)
+	return err
+}
+
+// SimpleFSSymlinkRead begins resolving arg.Path as a symlink, retrieved
+// with SimpleFSReadSymlink.
+func (b *Backend) SimpleFSSymlinkRead(ctx context.Context, arg keybase1.SimpleFSSymlinkReadArg) error {
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	n := b.lookup(key)
+	b.mu.Unlock()
+	st := b.newOp(arg.OpID, keybase1.AsyncOps_SYMLINK_READ)
+	if n == nil {
+		b.finishOp(arg.OpID, "", fmt.Errorf("fakefs: no such path %q", key))
+		return nil
+	}
+	if !n.isSym {
+		b.finishOp(arg.OpID, "", fmt.Errorf("fakefs: %q is not a symlink", key))
+		return nil
+	}
+	st.result = n.symlink
+	return nil
+}
+
+// SimpleFSReadSymlink returns the target resolved by SimpleFSSymlinkRead.
+func (b *Backend) SimpleFSReadSymlink(ctx context.Context, opID keybase1.OpID) (string, error) {
+	st, err := b.getOp(opID)
+	if err != nil {
+		return "", err
+	}
+	if st.err != nil {
+		return "", st.err
+	}
+	target, _ := st.result.(string)
+	return target, nil
+}
@@ -0,0 +1,200 @@
+package fakefs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// systemHiddenNames is the small set of platform litter files
+// ListFilter_FILTER_SYSTEM_HIDDEN hides, mirroring what the real KBFS
+// lister special-cases rather than anything dotfile-related.
+var systemHiddenNames = map[string]bool{
+	".DS_Store":   true,
+	"Thumbs.db":   true,
+	"desktop.ini": true,
+}
+
+// hiddenByFilter reports whether name should be excluded from a listing
+// under filter.
+func hiddenByFilter(name string, filter keybase1.ListFilter) bool {
+	switch filter {
+	case keybase1.ListFilter_FILTER_ALL_HIDDEN:
+		return strings.HasPrefix(name, ".")
+	case keybase1.ListFilter_FILTER_SYSTEM_HIDDEN:
+		return systemHiddenNames[name]
+	default:
+		return false
+	}
+}
+
+type listCursor struct {
+	entries  []keybase1.Dirent
+	offset   int
+	pageSize int
+}
+
+func (b *Backend) listEntries(key string, recursive bool, maxDepth int, filter keybase1.ListFilter) ([]keybase1.Dirent, error) {
+	n := b.lookup(key)
+	if n == nil {
+		return nil, fmt.Errorf("fakefs: no such path %q", key)
+	}
+	if !n.isDir {
+		return []keybase1.Dirent{b.direntFor(lastComponent(key), n)}, nil
+	}
+	var out []keybase1.Dirent
+	b.walk(key, n, recursive, maxDepth, 0, filter, &out)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (b *Backend) walk(prefix string, n *node, recursive bool, maxDepth, depth int, filter keybase1.ListFilter, out *[]keybase1.Dirent) {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if hiddenByFilter(name, filter) {
+			continue
+		}
+		child := n.children[name]
+		relName := name
+		if prefix != "" && prefix != "/" {
+			relName = prefix + "/" + name
+		}
+		*out = append(*out, b.direntFor(relName, child))
+		if recursive && child.isDir && (maxDepth == 0 || depth+1 < maxDepth) {
+			b.walk(relName, child, recursive, maxDepth, depth+1, filter, out)
+		}
+	}
+}
+
+func lastComponent(key string) string {
+	parts := splitPath(key)
+	if len(parts) == 0 {
+		return "/"
+	}
+	return parts[len(parts)-1]
+}
+
+func (b *Backend) beginList(ctx context.Context, opID keybase1.OpID, p keybase1.Path, recursive bool, maxDepth int, filter keybase1.ListFilter) error {
+	if err := b.checkInjectedError("simpleFSList"); err != nil {
+		return err
+	}
+	if err := b.delay(ctx.Done()); err != nil {
+		return err
+	}
+	key, err := pathKey(p)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := &opState{kind: keybase1.AsyncOps_LIST, progress: keybase1.OpProgress{Start: nowTime(), EndEstimate: nowTime()}}
+	entries, err := b.listEntries(key, recursive, maxDepth, filter)
+	st.result = keybase1.SimpleFSListResult{Entries: entries}
+	st.err = err
+	b.ops[opID] = st
+	return nil
+}
+
+// SimpleFSList begins a (non-recursive) directory listing, retrieved with
+// SimpleFSReadList.
+func (b *Backend) SimpleFSList(ctx context.Context, arg keybase1.SimpleFSListArg) error {
+	return b.beginList(ctx, arg.OpID, arg.Path, false, 0, arg.Filter)
+}
+
+// SimpleFSListRecursive begins a fully recursive directory listing.
+func (b *Backend) SimpleFSListRecursive(ctx context.Context, arg keybase1.SimpleFSListRecursiveArg) error {
+	return b.beginList(ctx, arg.OpID, arg.Path, true, 0, arg.Filter)
+}
+
+// SimpleFSListRecursiveToDepth begins a recursive directory listing bounded
+// to arg.Depth.
+func (b *Backend) SimpleFSListRecursiveToDepth(ctx context.Context, arg keybase1.SimpleFSListRecursiveToDepthArg) error {
+	return b.beginList(ctx, arg.OpID, arg.Path, true, arg.Depth, arg.Filter)
+}
+
+// SimpleFSReadList returns the result buffered by SimpleFSList(Recursive).
+func (b *Backend) SimpleFSReadList(ctx context.Context, opID keybase1.OpID) (keybase1.SimpleFSListResult, error) {
+	st, err := b.getOp(opID)
+	if err != nil {
+		return keybase1.SimpleFSListResult{}, err
+	}
+	if st.err != nil {
+		return keybase1.SimpleFSListResult{}, st.err
+	}
+	res, _ := st.result.(keybase1.SimpleFSListResult)
+	return res, nil
+}
+
+func (b *Backend) openIter(p keybase1.Path, filter keybase1.ListFilter, pageSize, maxDepth int, recursive bool) (keybase1.ListCursorID, error) {
+	key, err := pathKey(p)
+	if err != nil {
+		return "", err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entries, err := b.listEntries(key, recursive, maxDepth, filter)
+	if err != nil {
+		return "", err
+	}
+	b.opIDCounter++
+	cursorID := keybase1.ListCursorID(fmt.Sprintf("fakefs-cursor-%d", b.opIDCounter))
+	if pageSize <= 0 {
+		pageSize = len(entries)
+		if pageSize == 0 {
+			pageSize = 1
+		}
+	}
+	b.cursor[cursorID] = &listCursor{entries: entries, pageSize: pageSize}
+	return cursorID, nil
+}
+
+// SimpleFSListIter opens a cursor-backed directory listing.
+func (b *Backend) SimpleFSListIter(ctx context.Context, arg keybase1.SimpleFSListIterArg) (keybase1.ListCursorID, error) {
+	if err := b.checkInjectedError("simpleFSListIter"); err != nil {
+		return "", err
+	}
+	return b.openIter(arg.Path, arg.Filter, arg.PageSize, 0, false)
+}
+
+// SimpleFSListRecursiveIter opens a cursor-backed recursive directory
+// listing.
+func (b *Backend) SimpleFSListRecursiveIter(ctx context.Context, arg keybase1.SimpleFSListRecursiveIterArg) (keybase1.ListCursorID, error) {
+	if err := b.checkInjectedError("simpleFSListRecursiveIter"); err != nil {
+		return "", err
+	}
+	return b.openIter(arg.Path, arg.Filter, arg.PageSize, arg.MaxDepth, true)
+}
+
+// SimpleFSListIterNext returns the cursor's next page, or a zero-length
+// Entries once exhausted.
+func (b *Backend) SimpleFSListIterNext(ctx context.Context, cursorID keybase1.ListCursorID) (keybase1.SimpleFSListResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cur, ok := b.cursor[cursorID]
+	if !ok {
+		return keybase1.SimpleFSListResult{}, fmt.Errorf("fakefs: unknown cursor %q", cursorID)
+	}
+	end := cur.offset + cur.pageSize
+	if end > len(cur.entries) {
+		end = len(cur.entries)
+	}
+	page := cur.entries[cur.offset:end]
+	cur.offset = end
+	return keybase1.SimpleFSListResult{Entries: page}, nil
+}
+
+// SimpleFSListIterClose tears down a cursor opened by
+// SimpleFSListIter/SimpleFSListRecursiveIter.
+func (b *Backend) SimpleFSListIterClose(ctx context.Context, cursorID keybase1.ListCursorID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.cursor, cursorID)
+	return nil
+}
@@ -0,0 +1,196 @@
+package fakefs
+
+import (
+	"context"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// The methods in this file round out SimpleFSInterface for RPCs that are
+// peripheral to the data path the rest of the package models faithfully
+// (listing, reading, writing, copying, revisions, quota, subscriptions).
+// Callers exercising these just need a well-typed, deterministic response
+// rather than real KBFS semantics, so they return static zero-ish values.
+
+func (b *Backend) SimpleFSDumpDebuggingInfo(ctx context.Context) error { return nil }
+
+func (b *Backend) SimpleFSClearConflictState(ctx context.Context, path keybase1.Path) error {
+	return nil
+}
+
+func (b *Backend) SimpleFSFinishResolvingConflict(ctx context.Context, path keybase1.Path) error {
+	return nil
+}
+
+func (b *Backend) SimpleFSForceStuckConflict(ctx context.Context, path keybase1.Path) error {
+	return nil
+}
+
+func (b *Backend) SimpleFSSyncStatus(ctx context.Context, filter keybase1.ListFilter) (keybase1.FSSyncStatus, error) {
+	return keybase1.FSSyncStatus{}, nil
+}
+
+func (b *Backend) SimpleFSGetHTTPAddressAndToken(ctx context.Context) (keybase1.SimpleFSGetHTTPAddressAndTokenResponse, error) {
+	return keybase1.SimpleFSGetHTTPAddressAndTokenResponse{Address: "fakefs", Token: "fakefs-token"}, nil
+}
+
+func (b *Backend) SimpleFSUserEditHistory(ctx context.Context) ([]keybase1.FSFolderEditHistory, error) {
+	return nil, nil
+}
+
+func (b *Backend) SimpleFSFolderEditHistory(ctx context.Context, path keybase1.Path) (keybase1.FSFolderEditHistory, error) {
+	return keybase1.FSFolderEditHistory{}, nil
+}
+
+func (b *Backend) SimpleFSListFavorites(ctx context.Context) (keybase1.FavoritesResult, error) {
+	return keybase1.FavoritesResult{}, nil
+}
+
+// SimpleFSGetUserQuotaUsage reports real accounting against the fake's
+// tree size and configured Options.CapacityBytes, so callers asserting
+// on quota behavior (e.g. a copy that should fail once near the limit)
+// see consistent numbers.
+func (b *Backend) SimpleFSGetUserQuotaUsage(ctx context.Context) (keybase1.SimpleFSQuotaUsage, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return keybase1.SimpleFSQuotaUsage{
+		UsageBytes: b.usedBytes,
+		LimitBytes: b.opts.CapacityBytes,
+	}, nil
+}
+
+func (b *Backend) SimpleFSGetTeamQuotaUsage(ctx context.Context, teamName keybase1.TeamName) (keybase1.SimpleFSQuotaUsage, error) {
+	return b.SimpleFSGetUserQuotaUsage(ctx)
+}
+
+func (b *Backend) SimpleFSReset(ctx context.Context, arg keybase1.SimpleFSResetArg) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.root = newDir()
+	b.usedBytes = 0
+	return nil
+}
+
+func (b *Backend) SimpleFSFolderSyncConfigAndStatus(ctx context.Context, path keybase1.Path) (keybase1.FolderSyncConfigAndStatus, error) {
+	return keybase1.FolderSyncConfigAndStatus{}, nil
+}
+
+func (b *Backend) SimpleFSSetFolderSyncConfig(ctx context.Context, arg keybase1.SimpleFSSetFolderSyncConfigArg) error {
+	return nil
+}
+
+func (b *Backend) SimpleFSSyncConfigAndStatus(ctx context.Context, identifyBehavior *keybase1.TLFIdentifyBehavior) (keybase1.SyncConfigAndStatusRes, error) {
+	return keybase1.SyncConfigAndStatusRes{}, nil
+}
+
+func (b *Backend) SimpleFSAreWeConnectedToMDServer(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (b *Backend) SimpleFSCheckReachability(ctx context.Context) error { return nil }
+
+func (b *Backend) SimpleFSSetDebugLevel(ctx context.Context, level string) error { return nil }
+
+func (b *Backend) SimpleFSSettings(ctx context.Context) (keybase1.FSSettings, error) {
+	return keybase1.FSSettings{}, nil
+}
+
+func (b *Backend) SimpleFSSetNotificationThreshold(ctx context.Context, threshold int64) error {
+	return nil
+}
+
+func (b *Backend) SimpleFSObfuscatePath(ctx context.Context, path keybase1.Path) (string, error) {
+	key, err := pathKey(path)
+	if err != nil {
+		return "", err
+	}
+	return "obfuscated:" + key, nil
+}
+
+func (b *Backend) SimpleFSDeobfuscatePath(ctx context.Context, path keybase1.Path) ([]string, error) {
+	return nil, nil
+}
+
+func (b *Backend) SimpleFSGetStats(ctx context.Context) (keybase1.SimpleFSStats, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return keybase1.SimpleFSStats{PacerStats: keybase1.PacerStats{
+		UploadTokensAvailable:   b.transferLimits.UploadBytesPerSec,
+		DownloadTokensAvailable: b.transferLimits.DownloadBytesPerSec,
+	}}, nil
+}
+
+// SimpleFSSetTransferLimits and SimpleFSGetTransferLimits just round-trip
+// through Backend state -- the fake never actually throttles transfers.
+func (b *Backend) SimpleFSSetTransferLimits(ctx context.Context, limits keybase1.TransferLimits) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.transferLimits = limits
+	return nil
+}
+
+func (b *Backend) SimpleFSGetTransferLimits(ctx context.Context) (keybase1.TransferLimits, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.transferLimits, nil
+}
+
+// SimpleFSRegisterRemote, SimpleFSListRemotes, and SimpleFSRemoveRemote
+// just track registrations -- the fake routes every path, including
+// PathType_REMOTE, through the same in-memory tree rather than dialing a
+// real backend.
+func (b *Backend) SimpleFSRegisterRemote(ctx context.Context, config keybase1.RemoteConfig) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remotes[config.Name] = config
+	return nil
+}
+
+func (b *Backend) SimpleFSListRemotes(ctx context.Context) ([]keybase1.RemoteConfig, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	res := make([]keybase1.RemoteConfig, 0, len(b.remotes))
+	for _, config := range b.remotes {
+		res = append(res, config)
+	}
+	return res, nil
+}
+
+func (b *Backend) SimpleFSRemoveRemote(ctx context.Context, name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.remotes, name)
+	return nil
+}
+
+// SimpleFSCreateUnion, SimpleFSListUnions, and SimpleFSDeleteUnion track
+// union mount definitions -- the fake doesn't assemble an actual merged
+// view over Layers, since nothing in the package models a mount beyond
+// the single shared tree.
+func (b *Backend) SimpleFSCreateUnion(ctx context.Context, arg keybase1.SimpleFSCreateUnionArg) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.unions[arg.Name] = keybase1.UnionMount{
+		Name:          arg.Name,
+		Layers:        arg.Layers,
+		WritableLayer: arg.WritableLayer,
+	}
+	return nil
+}
+
+func (b *Backend) SimpleFSListUnions(ctx context.Context) ([]keybase1.UnionMount, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	res := make([]keybase1.UnionMount, 0, len(b.unions))
+	for _, mount := range b.unions {
+		res = append(res, mount)
+	}
+	return res, nil
+}
+
+func (b *Backend) SimpleFSDeleteUnion(ctx context.Context, name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.unions, name)
+	return nil
+}
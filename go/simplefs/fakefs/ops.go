@@ -0,0 +1,159 @@
+package fakefs
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// opState tracks one OpID's lifecycle. The fake completes every op
+// synchronously, so by the time the "begin" call returns, result/err are
+// already set and progress reports 100%; there's no async work to poll
+// for in a hermetic test.
+type opState struct {
+	kind     keybase1.AsyncOps
+	progress keybase1.OpProgress
+	result   interface{}
+	err      error
+	canceled bool
+
+	// openPath is set by SimpleFSOpen and consulted by the Read/Write
+	// family, which address an already-open file by OpID rather than by
+	// path.
+	openPath string
+}
+
+func (b *Backend) newOp(opID keybase1.OpID, kind keybase1.AsyncOps) *opState {
+	st := &opState{
+		kind: kind,
+		progress: keybase1.OpProgress{
+			Start:       nowTime(),
+			EndEstimate: nowTime(),
+			OpType:      kind,
+		},
+	}
+	b.mu.Lock()
+	b.ops[opID] = st
+	b.mu.Unlock()
+	return st
+}
+
+func (b *Backend) finishOp(opID keybase1.OpID, result interface{}, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.ops[opID]
+	if !ok {
+		return
+	}
+	st.result = result
+	st.err = err
+}
+
+func (b *Backend) getOp(opID keybase1.OpID) (*opState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.ops[opID]
+	if !ok {
+		return nil, fmt.Errorf("fakefs: unknown OpID %x", opID)
+	}
+	return st, nil
+}
+
+// SimpleFSMakeOpid generates a fresh, unused OpID.
+func (b *Backend) SimpleFSMakeOpid(ctx context.Context) (keybase1.OpID, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.opIDCounter++
+	var opID keybase1.OpID
+	binary.BigEndian.PutUint64(opID[8:], b.opIDCounter)
+	return opID, nil
+}
+
+// SimpleFSResumeOpid rehydrates a fresh OpID carrying priorOpID's
+// checkpoint state, so a caller can resume a recursive copy/move that was
+// interrupted mid-transfer. The fake never actually interrupts an op --
+// everything completes before the triggering call returns -- so this
+// just mints a new OpID and copies the finished checkpoint over; a real
+// implementation is what persists it across a daemon restart.
+func (b *Backend) SimpleFSResumeOpid(ctx context.Context, priorOpID keybase1.OpID) (keybase1.OpID, error) {
+	prior, err := b.getOp(priorOpID)
+	if err != nil {
+		return keybase1.OpID{}, err
+	}
+	if prior.progress.CheckpointPath == "" {
+		return keybase1.OpID{}, fmt.Errorf("fakefs: OpID %x has no resumable checkpoint", priorOpID)
+	}
+	opID, err := b.SimpleFSMakeOpid(ctx)
+	if err != nil {
+		return keybase1.OpID{}, err
+	}
+	st := b.newOp(opID, prior.kind)
+	b.mu.Lock()
+	st.progress.CheckpointPath = prior.progress.CheckpointPath
+	st.progress.CheckpointOffset = prior.progress.CheckpointOffset
+	st.progress.CheckpointRevision = prior.progress.CheckpointRevision
+	b.mu.Unlock()
+	return opID, nil
+}
+
+// SimpleFSClose tears down opID, whether it is a pending op or a
+// read/write handle from SimpleFSOpen; closing an unknown or
+// already-closed OpID is a no-op.
+func (b *Backend) SimpleFSClose(ctx context.Context, opID keybase1.OpID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.ops, opID)
+	return nil
+}
+
+// SimpleFSCancel marks opID canceled. Since every op completes
+// synchronously there's nothing in flight to interrupt; this just flags
+// the op so a subsequent Wait reports it as an error. Check still reports
+// the op's progress unchanged.
+func (b *Backend) SimpleFSCancel(ctx context.Context, opID keybase1.OpID) error {
+	st, err := b.getOp(opID)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	st.canceled = true
+	b.mu.Unlock()
+	return nil
+}
+
+// SimpleFSCheck reports opID's progress, which is always complete by the
+// time it's observable -- the fake has no async work to report partway
+// through.
+func (b *Backend) SimpleFSCheck(ctx context.Context, opID keybase1.OpID) (keybase1.OpProgress, error) {
+	st, err := b.getOp(opID)
+	if err != nil {
+		return keybase1.OpProgress{}, err
+	}
+	return st.progress, nil
+}
+
+// SimpleFSGetOps lists outstanding operations. The fake has none worth
+// describing in detail since every op finishes before Begin* returns, so
+// it reports an empty list rather than reconstructing OpDescription's
+// union shape for already-done work.
+func (b *Backend) SimpleFSGetOps(ctx context.Context) ([]keybase1.OpDescription, error) {
+	return nil, nil
+}
+
+// SimpleFSWait blocks for opID to finish, which it already has by the
+// time any caller can reach this -- kept for interface parity.
+func (b *Backend) SimpleFSWait(ctx context.Context, opID keybase1.OpID) error {
+	st, err := b.getOp(opID)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	canceled := st.canceled
+	b.mu.Unlock()
+	if canceled {
+		return fmt.Errorf("fakefs: OpID %x was canceled", opID)
+	}
+	return st.err
+}
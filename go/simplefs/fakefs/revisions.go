@@ -0,0 +1,66 @@
+package fakefs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// SimpleFSGetRevisions begins fetching arg.Path's synthetic revision
+// history, built from the revisions recorded on every SimpleFSWrite.
+// RevisionSpanType_LAST_FIVE caps it at five entries, the same as the
+// real RPC's span semantics.
+func (b *Backend) SimpleFSGetRevisions(ctx context.Context, arg keybase1.SimpleFSGetRevisionsArg) error {
+	if err := b.checkInjectedError("simpleFSGetRevisions"); err != nil {
+		return err
+	}
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	n := b.lookup(key)
+	b.mu.Unlock()
+
+	st := b.newOp(arg.OpID, keybase1.AsyncOps_GET_REVISIONS)
+	if n == nil {
+		b.finishOp(arg.OpID, nil, fmt.Errorf("fakefs: no such path %q", key))
+		return nil
+	}
+
+	revs := n.revisions
+	if arg.SpanType == keybase1.RevisionSpanType_LAST_FIVE && len(revs) > 5 {
+		revs = revs[len(revs)-5:]
+	}
+	out := make([]keybase1.DirentWithRevision, len(revs))
+	name := lastComponent(key)
+	for i, r := range revs {
+		out[i] = keybase1.DirentWithRevision{
+			Entry: keybase1.Dirent{
+				Time:       r.time,
+				Size:       len(r.data),
+				Name:       name,
+				DirentType: keybase1.DirentType_FILE,
+				Writable:   true,
+			},
+			Revision: r.rev,
+		}
+	}
+	st.result = keybase1.GetRevisionsResult{Revisions: out}
+	return nil
+}
+
+// SimpleFSReadRevisions returns the result buffered by
+// SimpleFSGetRevisions.
+func (b *Backend) SimpleFSReadRevisions(ctx context.Context, opID keybase1.OpID) (keybase1.GetRevisionsResult, error) {
+	st, err := b.getOp(opID)
+	if err != nil {
+		return keybase1.GetRevisionsResult{}, err
+	}
+	if st.err != nil {
+		return keybase1.GetRevisionsResult{}, st.err
+	}
+	res, _ := st.result.(keybase1.GetRevisionsResult)
+	return res, nil
+}
@@ -0,0 +1,87 @@
+package fakefs
+
+import (
+	"context"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+type subscription struct {
+	kbfsPath string
+	topic    keybase1.PathSubscriptionTopic
+	events   chan keybase1.FSPathEvent
+}
+
+// SimpleFSSubscribePath registers a subscription the fake can later
+// deliver events to via Events, the in-process equivalent of the
+// FSPathUpdated notifications a real KBFS daemon pushes out-of-band.
+func (b *Backend) SimpleFSSubscribePath(ctx context.Context, arg keybase1.SimpleFSSubscribePathArg) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[arg.SubscriptionID] = &subscription{
+		kbfsPath: arg.KbfsPath,
+		topic:    arg.Topic,
+		events:   make(chan keybase1.FSPathEvent, 16),
+	}
+	return nil
+}
+
+// SimpleFSSubscribeNonPath registers a non-path subscription. The fake
+// doesn't generate favorites/journal-status/online-status events, so
+// this just records the subscription for Unsubscribe symmetry.
+func (b *Backend) SimpleFSSubscribeNonPath(ctx context.Context, arg keybase1.SimpleFSSubscribeNonPathArg) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[arg.SubscriptionID] = &subscription{}
+	return nil
+}
+
+// SimpleFSUnsubscribe tears down a subscription registered by
+// SimpleFSSubscribePath/SimpleFSSubscribeNonPath.
+func (b *Backend) SimpleFSUnsubscribe(ctx context.Context, arg keybase1.SimpleFSUnsubscribeArg) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if sub, ok := b.subs[arg.SubscriptionID]; ok && sub.events != nil {
+		close(sub.events)
+	}
+	delete(b.subs, arg.SubscriptionID)
+	return nil
+}
+
+// Emit delivers event to every path subscription registered against
+// kbfsPath whose topic mask includes event.Type, for tests driving the
+// fake's filesystem directly to assert on subscription behavior.
+func (b *Backend) Emit(kbfsPath string, event keybase1.FSPathEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range b.subs {
+		if sub.events == nil || sub.kbfsPath != kbfsPath || sub.topic&event.Type == 0 {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// Events returns the channel a SimpleFSSubscribePath subscription's
+// events are delivered on, or nil if subscriptionID isn't registered.
+func (b *Backend) Events(subscriptionID string) <-chan keybase1.FSPathEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sub, ok := b.subs[subscriptionID]
+	if !ok {
+		return nil
+	}
+	return sub.events
+}
+
+// SimpleFSWatch begins watching arg.Path for filesystem notifications.
+// Like SimpleFSSubscribePath, real delivery happens out-of-band keyed by
+// OpID; the fake just completes the op and leaves event delivery to
+// Emit/Events for tests that need it.
+func (b *Backend) SimpleFSWatch(ctx context.Context, arg keybase1.SimpleFSWatchArg) error {
+	b.newOp(arg.OpID, keybase1.AsyncOps_WATCH)
+	return nil
+}
@@ -0,0 +1,114 @@
+package fakefs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// pathKey reduces any Path variant to the "/"-separated string used as
+// the fake tree's key space. LOCAL and KBFS paths share one tree, which
+// is good enough for a fake that only needs to exercise callers, not
+// model KBFS's TLF/device boundary. KBFS_ARCHIVED paths resolve to the
+// same key as their underlying KBFS path, and KBFS_KVSTORE paths to
+// "team/namespace/entry-key" -- the fake
+// doesn't model point-in-time history or the kvstore's separate
+// namespace beyond the synthetic revisions tracked per node.
+func pathKey(p keybase1.Path) (string, error) {
+	pathType, err := p.PathType()
+	if err != nil {
+		return "", err
+	}
+	switch pathType {
+	case keybase1.PathType_LOCAL:
+		return p.Local(), nil
+	case keybase1.PathType_KBFS:
+		return p.Kbfs().Path, nil
+	case keybase1.PathType_KBFS_ARCHIVED:
+		return p.KbfsArchived().Path, nil
+	case keybase1.PathType_KBFS_KVSTORE:
+		kv := p.KbfsKvstore()
+		return fmt.Sprintf("%s/%s/%s", kv.TeamName, kv.Namespace, kv.EntryKey), nil
+	case keybase1.PathType_REMOTE:
+		r := p.Remote()
+		return fmt.Sprintf("remote/%s/%s", r.RemoteName, r.Key), nil
+	default:
+		return "", fmt.Errorf("fakefs: unknown path type %v", pathType)
+	}
+}
+
+func splitPath(key string) []string {
+	var parts []string
+	for _, part := range strings.Split(key, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// lookup returns the node at key, or nil if it (or an ancestor) doesn't
+// exist.
+func (b *Backend) lookup(key string) *node {
+	cur := b.root
+	for _, part := range splitPath(key) {
+		if !cur.isDir {
+			return nil
+		}
+		next, ok := cur.children[part]
+		if !ok {
+			return nil
+		}
+		cur = next
+	}
+	return cur
+}
+
+// mkdirParents walks key's parent directories, creating any that don't
+// exist, and returns the parent node and the leaf name.
+func (b *Backend) mkdirParents(key string) (parent *node, leaf string, err error) {
+	parts := splitPath(key)
+	if len(parts) == 0 {
+		return nil, "", fmt.Errorf("fakefs: empty path")
+	}
+	cur := b.root
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := cur.children[part]
+		if !ok {
+			next = newDir()
+			cur.children[part] = next
+		}
+		if !next.isDir {
+			return nil, "", fmt.Errorf("fakefs: %q is not a directory", part)
+		}
+		cur = next
+	}
+	return cur, parts[len(parts)-1], nil
+}
+
+func direntType(n *node) keybase1.DirentType {
+	switch {
+	case n.isSym:
+		return keybase1.DirentType_SYM
+	case n.isDir:
+		return keybase1.DirentType_DIR
+	default:
+		return keybase1.DirentType_FILE
+	}
+}
+
+func (b *Backend) direntFor(name string, n *node) keybase1.Dirent {
+	d := keybase1.Dirent{
+		Time:       n.mtime,
+		Size:       len(n.data),
+		Name:       name,
+		DirentType: direntType(n),
+		Writable:   true,
+	}
+	if n.isSym {
+		target := n.symlink
+		d.SymlinkTarget = &target
+	}
+	return d
+}
@@ -0,0 +1,57 @@
+package fakefs
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+func TestPathKeyLocal(t *testing.T) {
+	key, err := pathKey(keybase1.NewPathWithLocal("/tmp/foo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if key != "/tmp/foo" {
+		t.Fatalf("got %q, want %q", key, "/tmp/foo")
+	}
+}
+
+func TestPathKeyKbfs(t *testing.T) {
+	key, err := pathKey(keybase1.NewPathWithKbfs(keybase1.KBFSPath{Path: "/private/alice/foo"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if key != "/private/alice/foo" {
+		t.Fatalf("got %q, want %q", key, "/private/alice/foo")
+	}
+}
+
+func TestPathKeyKbfsKvstore(t *testing.T) {
+	key, err := pathKey(keybase1.NewPathWithKbfsKvstore(keybase1.KBFSKVStorePath{
+		TeamName:  "acme",
+		Namespace: "ns",
+		EntryKey:  "key",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "acme/ns/key"; key != want {
+		t.Fatalf("got %q, want %q", key, want)
+	}
+}
+
+func TestPathKeyRemote(t *testing.T) {
+	key, err := pathKey(keybase1.NewPathWithRemote(keybase1.RemotePath{RemoteName: "s3", Key: "bucket/obj"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "remote/s3/bucket/obj"; key != want {
+		t.Fatalf("got %q, want %q", key, want)
+	}
+}
+
+func TestPathKeyUnknownType(t *testing.T) {
+	if _, err := pathKey(keybase1.Path{}); err == nil {
+		t.Fatal("expected an error for a Path with no variant set")
+	}
+}
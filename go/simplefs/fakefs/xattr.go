@@ -0,0 +1,117 @@
+package fakefs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// SimpleFSGetXAttr returns the value of one extended attribute from
+// arg.Path's sidecar xattr map.
+func (b *Backend) SimpleFSGetXAttr(ctx context.Context, arg keybase1.SimpleFSGetXAttrArg) (keybase1.XAttr, error) {
+	if err := b.checkInjectedError("simpleFSGetXAttr"); err != nil {
+		return keybase1.XAttr{}, err
+	}
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return keybase1.XAttr{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(key)
+	if n == nil {
+		return keybase1.XAttr{}, fmt.Errorf("fakefs: no such path %q", key)
+	}
+	x, ok := n.xattrs[arg.Name]
+	if !ok {
+		return keybase1.XAttr{}, fmt.Errorf("fakefs: no such xattr %q on %q", arg.Name, key)
+	}
+	return x, nil
+}
+
+// SimpleFSSetXAttr sets one extended attribute on arg.Path, honoring
+// arg.Flags' create/replace semantics the same way Linux's setxattr(2)
+// does.
+func (b *Backend) SimpleFSSetXAttr(ctx context.Context, arg keybase1.SimpleFSSetXAttrArg) error {
+	if err := b.checkInjectedError("simpleFSSetXAttr"); err != nil {
+		return err
+	}
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(key)
+	if n == nil {
+		return fmt.Errorf("fakefs: no such path %q", key)
+	}
+	_, exists := n.xattrs[arg.Name]
+	switch arg.Flags {
+	case keybase1.XAttrSetFlags_CREATE:
+		if exists {
+			return fmt.Errorf("fakefs: xattr %q already exists on %q", arg.Name, key)
+		}
+	case keybase1.XAttrSetFlags_REPLACE:
+		if !exists {
+			return fmt.Errorf("fakefs: no such xattr %q on %q", arg.Name, key)
+		}
+	}
+	if n.xattrs == nil {
+		n.xattrs = make(map[string]keybase1.XAttr)
+	}
+	n.xattrs[arg.Name] = keybase1.XAttr{Name: arg.Name, Value: append([]byte(nil), arg.Value...)}
+	return nil
+}
+
+// SimpleFSListXAttrs lists the extended attributes set on arg.Path, sorted
+// by name for deterministic output.
+func (b *Backend) SimpleFSListXAttrs(ctx context.Context, arg keybase1.SimpleFSListXAttrsArg) ([]keybase1.XAttr, error) {
+	if err := b.checkInjectedError("simpleFSListXAttrs"); err != nil {
+		return nil, err
+	}
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(key)
+	if n == nil {
+		return nil, fmt.Errorf("fakefs: no such path %q", key)
+	}
+	names := make([]string, 0, len(n.xattrs))
+	for name := range n.xattrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := make([]keybase1.XAttr, 0, len(names))
+	for _, name := range names {
+		res = append(res, n.xattrs[name])
+	}
+	return res, nil
+}
+
+// SimpleFSRemoveXAttr removes one extended attribute from arg.Path.
+func (b *Backend) SimpleFSRemoveXAttr(ctx context.Context, arg keybase1.SimpleFSRemoveXAttrArg) error {
+	if err := b.checkInjectedError("simpleFSRemoveXAttr"); err != nil {
+		return err
+	}
+	key, err := pathKey(arg.Path)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.lookup(key)
+	if n == nil {
+		return fmt.Errorf("fakefs: no such path %q", key)
+	}
+	if _, ok := n.xattrs[arg.Name]; !ok {
+		return fmt.Errorf("fakefs: no such xattr %q on %q", arg.Name, key)
+	}
+	delete(n.xattrs, arg.Name)
+	return nil
+}
@@ -0,0 +1,33 @@
+package httpgw
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// etagFor computes a strong ETag for path from its KBFS block hash, so a
+// conditional request (If-None-Match/If-Range) can tell whether content
+// actually changed rather than just comparing mtimes.
+func etagFor(ctx context.Context, cli keybase1.SimpleFSClient, path keybase1.Path) (string, error) {
+	opID, err := cli.SimpleFSMakeOpid(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer cli.SimpleFSClose(ctx, opID)
+
+	if err := cli.SimpleFSHash(ctx, keybase1.SimpleFSHashArg{
+		OpID: opID,
+		Path: path,
+		Type: keybase1.HashType_SHA256,
+	}); err != nil {
+		return "", err
+	}
+	res, err := cli.SimpleFSReadHash(ctx, opID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%q", hex.EncodeToString(res.Digest)), nil
+}
@@ -0,0 +1,60 @@
+// Package httpgw implements the HTTP handler served at the address
+// returned by SimpleFSGetHTTPAddressAndToken: GET/HEAD/PUT against
+// /<kbfs-path>?token=<token>, backed by SimpleFS RPCs. It supports Range
+// and conditional requests on download and Content-Range resumable
+// uploads, so large transfers survive network drops and video/audio
+// players can seek without re-downloading the whole file.
+package httpgw
+
+import (
+	"net/http"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// TokenValidator checks a token minted by SimpleFSGetHTTPAddressAndToken.
+// A real implementation also scopes the token to a path prefix; the
+// interface here only needs to say yes or no, since the gateway always
+// operates on the KBFS path in the request URL.
+type TokenValidator interface {
+	ValidateToken(token string) bool
+}
+
+// Gateway serves the SimpleFS HTTP token gateway over a SimpleFS RPC
+// client. It holds no per-request download state; resumable uploads are
+// tracked by uploads, keyed by keybase1.UploadID.
+type Gateway struct {
+	cli     keybase1.SimpleFSClient
+	tokens  TokenValidator
+	uploads *uploadTracker
+}
+
+// NewGateway constructs a Gateway that validates every request's token
+// query parameter with tokens before serving it over cli.
+func NewGateway(cli keybase1.SimpleFSClient, tokens TokenValidator) *Gateway {
+	return &Gateway{cli: cli, tokens: tokens, uploads: newUploadTracker()}
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !g.tokens.ValidateToken(r.URL.Query().Get("token")) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	path := kbfsPath(r.URL.Path)
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		g.serveDownload(w, r, path)
+	case http.MethodPut:
+		g.serveUpload(w, r, path)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// kbfsPath turns the gateway's URL path (e.g. "/private/alice/foo.txt")
+// into the KBFS path SimpleFS expects (e.g. "/keybase/private/alice/foo.txt").
+func kbfsPath(urlPath string) keybase1.Path {
+	return keybase1.NewPathWithKbfs(keybase1.KBFSPath{Path: "/keybase" + urlPath})
+}
@@ -0,0 +1,76 @@
+package httpgw
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// byteRange is an inclusive [start, end] byte range, RFC 7233 style.
+type byteRange struct {
+	start, end int64
+}
+
+func (r byteRange) length() int64 { return r.end - r.start + 1 }
+
+func (r byteRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.start, r.end, size)
+}
+
+// parseRange parses a Range header value (e.g. "bytes=0-499,500-999")
+// against a resource of the given size. A missing header returns a nil
+// slice, meaning "serve the whole thing".
+func parseRange(header string, size int64) ([]byteRange, error) {
+	if header == "" {
+		return nil, nil
+	}
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return nil, fmt.Errorf("httpgw: unsupported Range unit in %q", header)
+	}
+
+	var ranges []byteRange
+	for _, part := range strings.Split(strings.TrimPrefix(header, prefix), ",") {
+		part = strings.TrimSpace(part)
+		dash := strings.IndexByte(part, '-')
+		if dash < 0 {
+			return nil, fmt.Errorf("httpgw: malformed range %q", part)
+		}
+		startStr, endStr := part[:dash], part[dash+1:]
+
+		var start, end int64
+		switch {
+		case startStr == "":
+			// Suffix range: "-500" means the last 500 bytes.
+			suffix, err := strconv.ParseInt(endStr, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("httpgw: malformed range %q: %w", part, err)
+			}
+			start = size - suffix
+			if start < 0 {
+				start = 0
+			}
+			end = size - 1
+		default:
+			var err error
+			start, err = strconv.ParseInt(startStr, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("httpgw: malformed range %q: %w", part, err)
+			}
+			if endStr == "" {
+				end = size - 1
+			} else if end, err = strconv.ParseInt(endStr, 10, 64); err != nil {
+				return nil, fmt.Errorf("httpgw: malformed range %q: %w", part, err)
+			}
+		}
+
+		if start > end || start >= size {
+			return nil, fmt.Errorf("httpgw: range %q out of bounds for size %d", part, size)
+		}
+		if end >= size {
+			end = size - 1
+		}
+		ranges = append(ranges, byteRange{start, end})
+	}
+	return ranges, nil
+}
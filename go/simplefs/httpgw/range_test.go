@@ -0,0 +1,111 @@
+package httpgw
+
+import "testing"
+
+func TestParseRangeNoHeader(t *testing.T) {
+	ranges, err := parseRange("", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ranges != nil {
+		t.Fatalf("expected nil ranges for missing header, got %v", ranges)
+	}
+}
+
+func TestParseRangeSingle(t *testing.T) {
+	ranges, err := parseRange("bytes=0-499", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []byteRange{{start: 0, end: 499}}
+	if len(ranges) != 1 || ranges[0] != want[0] {
+		t.Fatalf("got %v, want %v", ranges, want)
+	}
+}
+
+func TestParseRangeMulti(t *testing.T) {
+	ranges, err := parseRange("bytes=0-99,200-299", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []byteRange{{start: 0, end: 99}, {start: 200, end: 299}}
+	if len(ranges) != len(want) || ranges[0] != want[0] || ranges[1] != want[1] {
+		t.Fatalf("got %v, want %v", ranges, want)
+	}
+}
+
+func TestParseRangeSuffix(t *testing.T) {
+	ranges, err := parseRange("bytes=-500", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := byteRange{start: 500, end: 999}
+	if len(ranges) != 1 || ranges[0] != want {
+		t.Fatalf("got %v, want %v", ranges, want)
+	}
+}
+
+func TestParseRangeSuffixLargerThanSize(t *testing.T) {
+	ranges, err := parseRange("bytes=-5000", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := byteRange{start: 0, end: 999}
+	if len(ranges) != 1 || ranges[0] != want {
+		t.Fatalf("got %v, want %v", ranges, want)
+	}
+}
+
+func TestParseRangeOpenEnded(t *testing.T) {
+	ranges, err := parseRange("bytes=900-", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := byteRange{start: 900, end: 999}
+	if len(ranges) != 1 || ranges[0] != want {
+		t.Fatalf("got %v, want %v", ranges, want)
+	}
+}
+
+func TestParseRangeEndClampedToSize(t *testing.T) {
+	ranges, err := parseRange("bytes=0-5000", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := byteRange{start: 0, end: 999}
+	if len(ranges) != 1 || ranges[0] != want {
+		t.Fatalf("got %v, want %v", ranges, want)
+	}
+}
+
+func TestParseRangeUnsupportedUnit(t *testing.T) {
+	if _, err := parseRange("lines=0-10", 1000); err == nil {
+		t.Fatal("expected an error for a non-bytes unit")
+	}
+}
+
+func TestParseRangeMalformed(t *testing.T) {
+	for _, header := range []string{"bytes=abc", "bytes=100", "bytes=abc-200"} {
+		if _, err := parseRange(header, 1000); err == nil {
+			t.Fatalf("expected an error for malformed range %q", header)
+		}
+	}
+}
+
+func TestParseRangeOutOfBounds(t *testing.T) {
+	for _, header := range []string{"bytes=1000-1999", "bytes=500-100"} {
+		if _, err := parseRange(header, 1000); err == nil {
+			t.Fatalf("expected an error for out-of-bounds range %q", header)
+		}
+	}
+}
+
+func TestByteRangeLengthAndContentRange(t *testing.T) {
+	r := byteRange{start: 100, end: 199}
+	if got := r.length(); got != 100 {
+		t.Fatalf("length() = %d, want 100", got)
+	}
+	if got, want := r.contentRange(1000), "bytes 100-199/1000"; got != want {
+		t.Fatalf("contentRange() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+package httpgw
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// uploadTracker accumulates the PartInfo manifest for an in-progress
+// resumable upload across PUT requests, so SimpleFSCommitMultipart can be
+// called once the last Content-Range chunk arrives. It's the HTTP
+// gateway's analogue of the SFTP gateway's 1:1 OpID-per-handle mapping --
+// here the durable key is an UploadID instead, since uploads span multiple
+// independent HTTP requests rather than one held-open connection.
+type uploadTracker struct {
+	mu    sync.Mutex
+	parts map[keybase1.UploadID][]keybase1.PartInfo
+}
+
+func newUploadTracker() *uploadTracker {
+	return &uploadTracker{parts: make(map[keybase1.UploadID][]keybase1.PartInfo)}
+}
+
+func (t *uploadTracker) addPart(id keybase1.UploadID, part keybase1.PartInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.parts[id] = append(t.parts[id], part)
+}
+
+func (t *uploadTracker) take(id keybase1.UploadID) []keybase1.PartInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parts := t.parts[id]
+	delete(t.parts, id)
+	return parts
+}
+
+func newUploadID() keybase1.UploadID {
+	var buf [16]byte
+	rand.Read(buf[:])
+	return keybase1.UploadID(hex.EncodeToString(buf[:]))
+}
+
+var contentRangeRe = regexp.MustCompile(`^bytes (\d+)-(\d+)/(\d+)$`)
+
+// serveUpload handles PUT, resuming an upload identified by the
+// "Upload-ID" header (minting one if absent) and writing the request
+// body's Content-Range chunk via SimpleFSWriteAt. Once the chunk covering
+// the end of the file arrives, it commits the accumulated manifest with
+// SimpleFSCommitMultipart, making the upload visible at path. Each chunk
+// mints its own OpID via SimpleFSResumeUpload, so it's closed before
+// returning rather than accumulating one ops-map entry per chunk for the
+// life of the upload.
+func (g *Gateway) serveUpload(w http.ResponseWriter, r *http.Request, path keybase1.Path) {
+	ctx := r.Context()
+
+	uploadID := keybase1.UploadID(r.Header.Get("Upload-ID"))
+	if uploadID == "" {
+		uploadID = newUploadID()
+	}
+
+	m := contentRangeRe.FindStringSubmatch(r.Header.Get("Content-Range"))
+	if m == nil {
+		http.Error(w, "httpgw: PUT requires a Content-Range: bytes start-end/total header", http.StatusBadRequest)
+		return
+	}
+	start, _ := strconv.ParseInt(m[1], 10, 64)
+	end, _ := strconv.ParseInt(m[2], 10, 64)
+	total, _ := strconv.ParseInt(m[3], 10, 64)
+
+	resumeRes, err := g.cli.SimpleFSResumeUpload(ctx, keybase1.SimpleFSResumeUploadArg{
+		Path:     path,
+		UploadID: uploadID,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	opID := resumeRes.OpID
+	defer g.cli.SimpleFSClose(ctx, opID)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := g.cli.SimpleFSWriteAt(ctx, keybase1.SimpleFSWriteAtArg{
+		OpID:    opID,
+		Offset:  start,
+		Content: body,
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	g.uploads.addPart(uploadID, keybase1.PartInfo{Offset: start, Size: int64(len(body))})
+
+	w.Header().Set("Upload-ID", string(uploadID))
+
+	if end+1 < total {
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", end))
+		w.WriteHeader(http.StatusPermanentRedirect)
+		return
+	}
+
+	if err := g.cli.SimpleFSCommitMultipart(ctx, keybase1.SimpleFSCommitMultipartArg{
+		OpID:  opID,
+		Parts: g.uploads.take(uploadID),
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
@@ -0,0 +1,64 @@
+package simplefs
+
+import (
+	"context"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// ListIterator pages through a directory listing backed by a server-side
+// cursor, instead of buffering the whole directory behind an OpID. Callers
+// must call Close when done; a dropped ListIterator still gets torn down
+// server-side eventually, but Close releases it immediately.
+type ListIterator interface {
+	// Next returns the next page of entries, or a zero-length slice once
+	// the iteration is exhausted.
+	Next(ctx context.Context) ([]keybase1.Dirent, error)
+	Close() error
+}
+
+type cursorIterator struct {
+	cli      keybase1.SimpleFSClient
+	cursorID keybase1.ListCursorID
+}
+
+func (it *cursorIterator) Next(ctx context.Context) ([]keybase1.Dirent, error) {
+	res, err := it.cli.SimpleFSListIterNext(ctx, it.cursorID)
+	if err != nil {
+		return nil, err
+	}
+	return res.Entries, nil
+}
+
+func (it *cursorIterator) Close() error {
+	return it.cli.SimpleFSListIterClose(context.Background(), it.cursorID)
+}
+
+// ListIter opens a ListIterator over path, PageSize entries at a time.
+func ListIter(ctx context.Context, cli keybase1.SimpleFSClient, path keybase1.Path, filter keybase1.ListFilter, pageSize int) (ListIterator, error) {
+	cursorID, err := cli.SimpleFSListIter(ctx, keybase1.SimpleFSListIterArg{
+		Path:     path,
+		Filter:   filter,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cursorIterator{cli: cli, cursorID: cursorID}, nil
+}
+
+// ListRecursiveIter opens a ListIterator that descends into
+// subdirectories up to maxDepth (zero means unlimited), PageSize entries
+// at a time.
+func ListRecursiveIter(ctx context.Context, cli keybase1.SimpleFSClient, path keybase1.Path, filter keybase1.ListFilter, pageSize, maxDepth int) (ListIterator, error) {
+	cursorID, err := cli.SimpleFSListRecursiveIter(ctx, keybase1.SimpleFSListRecursiveIterArg{
+		Path:     path,
+		Filter:   filter,
+		PageSize: pageSize,
+		MaxDepth: maxDepth,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cursorIterator{cli: cli, cursorID: cursorID}, nil
+}
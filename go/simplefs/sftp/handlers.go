@@ -0,0 +1,228 @@
+package sftp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/sftp"
+)
+
+// fileSystem implements sftp.Handlers (FileReader/FileWriter/FileCmder/
+// FileLister) for one SFTP session, translating requests into
+// SimpleFSMakeOpid-scoped SimpleFS RPCs. Every open file handle it hands
+// back to the sftp package is backed 1:1 by a KBFS OpID, closed with
+// SimpleFSClose when the handle is released.
+type fileSystem struct {
+	ctx context.Context
+	cli keybase1.SimpleFSClient
+	id  *Identity
+}
+
+func newFileSystem(ctx context.Context, cli keybase1.SimpleFSClient, id *Identity) *fileSystem {
+	return &fileSystem{ctx: ctx, cli: cli, id: id}
+}
+
+// Fileread opens Filepath for reading and returns an io.ReaderAt backed by
+// the resulting OpID; SimpleFSClose runs when the returned value's Close
+// method is called.
+func (fs *fileSystem) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	opID, err := fs.open(r.Filepath, keybase1.OpenFlags_READ)
+	if err != nil {
+		return nil, err
+	}
+	return &opHandle{ctx: fs.ctx, cli: fs.cli, opID: opID}, nil
+}
+
+// Filewrite opens Filepath for writing, creating it if necessary, and
+// returns an io.WriterAt backed by the resulting OpID via SimpleFSWriteAt.
+func (fs *fileSystem) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	opID, err := fs.open(r.Filepath, keybase1.OpenFlags_WRITE|keybase1.OpenFlags_REPLACE)
+	if err != nil {
+		return nil, err
+	}
+	return &opHandle{ctx: fs.ctx, cli: fs.cli, opID: opID}, nil
+}
+
+func (fs *fileSystem) open(filepath string, flags keybase1.OpenFlags) (keybase1.OpID, error) {
+	opID, err := fs.cli.SimpleFSMakeOpid(fs.ctx)
+	if err != nil {
+		return opID, err
+	}
+	err = fs.cli.SimpleFSOpen(fs.ctx, keybase1.SimpleFSOpenArg{
+		OpID:  opID,
+		Dest:  toKBFSPath(fs.id, filepath),
+		Flags: flags,
+	})
+	if err != nil {
+		fs.cli.SimpleFSClose(fs.ctx, opID)
+		return opID, err
+	}
+	return opID, nil
+}
+
+// Filecmd handles the SFTP operations that don't move file content:
+// Rename, Rmdir, Remove, Mkdir, and Setstat (a no-op, since SimpleFS has
+// no chmod/chown equivalent).
+func (fs *fileSystem) Filecmd(r *sftp.Request) error {
+	switch r.Method {
+	case "Rename":
+		opID, err := fs.cli.SimpleFSMakeOpid(fs.ctx)
+		if err != nil {
+			return err
+		}
+		defer fs.cli.SimpleFSClose(fs.ctx, opID)
+		return fs.cli.SimpleFSMove(fs.ctx, keybase1.SimpleFSMoveArg{
+			OpID: opID,
+			Src:  toKBFSPath(fs.id, r.Filepath),
+			Dest: toKBFSPath(fs.id, r.Target),
+		})
+	case "Rmdir", "Remove":
+		opID, err := fs.cli.SimpleFSMakeOpid(fs.ctx)
+		if err != nil {
+			return err
+		}
+		defer fs.cli.SimpleFSClose(fs.ctx, opID)
+		return fs.cli.SimpleFSRemove(fs.ctx, keybase1.SimpleFSRemoveArg{
+			OpID:      opID,
+			Path:      toKBFSPath(fs.id, r.Filepath),
+			Recursive: r.Method == "Rmdir",
+		})
+	case "Mkdir":
+		opID, err := fs.cli.SimpleFSMakeOpid(fs.ctx)
+		if err != nil {
+			return err
+		}
+		defer fs.cli.SimpleFSClose(fs.ctx, opID)
+		return fs.cli.SimpleFSOpen(fs.ctx, keybase1.SimpleFSOpenArg{
+			OpID:  opID,
+			Dest:  toKBFSPath(fs.id, r.Filepath),
+			Flags: keybase1.OpenFlags_DIRECTORY | keybase1.OpenFlags_REPLACE,
+		})
+	case "Setstat":
+		return nil
+	default:
+		return fmt.Errorf("sftp: unsupported Filecmd method %q", r.Method)
+	}
+}
+
+// Filelist handles List (readdir), Stat, and the statvfs@openssh.com
+// extension surfaced by the sftp package as a synthetic Filelist method.
+func (fs *fileSystem) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	switch r.Method {
+	case "List":
+		return fs.list(r.Filepath)
+	case "Stat":
+		return fs.stat(r.Filepath)
+	case "Statvfs":
+		return fs.statvfs(r.Filepath)
+	default:
+		return nil, fmt.Errorf("sftp: unsupported Filelist method %q", r.Method)
+	}
+}
+
+func (fs *fileSystem) list(filepath string) (sftp.ListerAt, error) {
+	opID, err := fs.cli.SimpleFSMakeOpid(fs.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.cli.SimpleFSClose(fs.ctx, opID)
+
+	if err := fs.cli.SimpleFSList(fs.ctx, keybase1.SimpleFSListArg{
+		OpID: opID,
+		Path: toKBFSPath(fs.id, filepath),
+	}); err != nil {
+		return nil, err
+	}
+	res, err := fs.cli.SimpleFSReadList(fs.ctx, opID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]os.FileInfo, len(res.Entries))
+	for i, entry := range res.Entries {
+		infos[i] = direntFileInfo(entry)
+	}
+	return listerAt(infos), nil
+}
+
+func (fs *fileSystem) stat(filepath string) (sftp.ListerAt, error) {
+	dirent, err := fs.cli.SimpleFSStat(fs.ctx, keybase1.SimpleFSStatArg{
+		Path: toKBFSPath(fs.id, filepath),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return listerAt{direntFileInfo(dirent)}, nil
+}
+
+func direntFileInfo(d keybase1.Dirent) os.FileInfo {
+	mode := os.FileMode(0644)
+	if d.DirentType == keybase1.DirentType_DIR {
+		mode = os.ModeDir | 0755
+	}
+	if d.DirentType == keybase1.DirentType_SYM {
+		mode = os.ModeSymlink | 0777
+	}
+	return sftp.NewFileInfoOption(d.Name,
+		sftp.WithFileInfoSize(int64(d.Size)),
+		sftp.WithFileInfoMode(mode),
+		sftp.WithFileInfoModTime(d.Time.Time()),
+	)
+}
+
+// listerAt adapts a plain []os.FileInfo to the sftp.ListerAt interface the
+// sftp package paginates directory listings through.
+type listerAt []os.FileInfo
+
+func (l listerAt) ListAt(dst []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(dst, l[offset:])
+	if n < len(dst) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// opHandle is an open SimpleFS OpID exposed as an io.ReaderAt/io.WriterAt/
+// io.Closer, the shape the sftp package expects from Fileread/Filewrite.
+type opHandle struct {
+	ctx  context.Context
+	cli  keybase1.SimpleFSClient
+	opID keybase1.OpID
+}
+
+func (h *opHandle) ReadAt(p []byte, off int64) (int, error) {
+	res, err := h.cli.SimpleFSRead(h.ctx, keybase1.SimpleFSReadArg{
+		OpID:   h.opID,
+		Offset: off,
+		Size:   len(p),
+	})
+	if err != nil {
+		return 0, err
+	}
+	n := copy(p, res.Data)
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (h *opHandle) WriteAt(p []byte, off int64) (int, error) {
+	if err := h.cli.SimpleFSWriteAt(h.ctx, keybase1.SimpleFSWriteAtArg{
+		OpID:    h.opID,
+		Offset:  off,
+		Content: p,
+	}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (h *opHandle) Close() error {
+	return h.cli.SimpleFSClose(h.ctx, h.opID)
+}
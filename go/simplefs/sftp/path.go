@@ -0,0 +1,31 @@
+package sftp
+
+import (
+	"path"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// toKBFSPath turns an SFTP-visible absolute path (e.g.
+// "/private/alice/docs/foo.txt") into the KBFS path SimpleFS expects
+// (e.g. "/keybase/private/alice/docs/foo.txt"), scoping it under root if
+// the session was authenticated with one.
+func toKBFSPath(id *Identity, p string) keybase1.Path {
+	p = path.Clean("/" + p)
+	if id.Root != nil {
+		return keybase1.NewPathWithKbfs(keybase1.KBFSPath{Path: joinKBFS(id.Root.Kbfs().Path, p)})
+	}
+	return keybase1.NewPathWithKbfs(keybase1.KBFSPath{Path: "/keybase" + p})
+}
+
+func joinKBFS(root, rel string) string {
+	return strings.TrimSuffix(root, "/") + rel
+}
+
+// pathKey returns a stable string for a Path, used as the SFTP session's
+// advertised root in the SSH connection's extension data. It only needs to
+// round-trip KBFS paths; other Path variants aren't valid session roots.
+func pathKey(p keybase1.Path) (string, error) {
+	return p.Kbfs().Path, nil
+}
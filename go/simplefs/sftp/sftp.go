@@ -0,0 +1,168 @@
+// Package sftp exposes the SimpleFS client surface as an SFTP server, so
+// ops tooling and legacy clients (rsync, WinSCP, backup software) can reach
+// /keybase/{private,public,team}/... TLFs without mounting FUSE/Dokan and
+// without the browser-only SimpleFSGetHTTPAddressAndToken flow.
+//
+// A Server wraps a keybase1.SimpleFSClient; Serve negotiates SSH on an
+// already-accepted net.Conn and dispatches the sftp subsystem channel to a
+// Handlers implementation (handlers.go) that translates SFTP requests into
+// SimpleFSMakeOpid-scoped SimpleFS RPCs, one OpID per open SFTP file
+// handle.
+package sftp
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// Identity is the authenticated principal behind an SFTP session. Root, if
+// set, confines the session to a single TLF the way a per-team access
+// token mints scoped HTTP downloads today; a nil Root allows the full
+// /keybase/{private,public,team} namespace a device-signing-key login
+// would see.
+type Identity struct {
+	Username string
+	Root     *keybase1.Path
+}
+
+// Authenticator authenticates incoming SFTP connections, either by device
+// signing key (the normal interactive login) or by a bearer token minted
+// the same way SimpleFSGetHTTPAddressAndToken mints one for the HTTP
+// gateway, scoped to a single TLF.
+type Authenticator interface {
+	AuthenticatePublicKey(conn ssh.ConnMetadata, key ssh.PublicKey) (*Identity, error)
+	AuthenticateToken(conn ssh.ConnMetadata, token string) (*Identity, error)
+}
+
+// Server is an SFTP gateway backed by a SimpleFS RPC client. It holds no
+// per-connection state; every accepted net.Conn gets its own ssh.ServerConn
+// and sftp.RequestServer.
+type Server struct {
+	cli  keybase1.SimpleFSClient
+	auth Authenticator
+	key  ssh.Signer
+}
+
+// NewServer constructs a Server that authenticates connections with auth
+// and signs its SSH host key with key.
+func NewServer(cli keybase1.SimpleFSClient, auth Authenticator, key ssh.Signer) *Server {
+	return &Server{cli: cli, auth: auth, key: key}
+}
+
+// Serve takes ownership of an already-accepted net.Conn, negotiates SSH,
+// and serves the sftp subsystem on it until the client disconnects or ctx
+// is canceled. It blocks until the session ends.
+func (s *Server) Serve(ctx context.Context, conn net.Conn) error {
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			id, err := s.auth.AuthenticatePublicKey(conn, key)
+			if err != nil {
+				return nil, err
+			}
+			return identityPermissions(id), nil
+		},
+		// PasswordCallback doubles as token auth: WinSCP/rsync-over-ssh
+		// users paste a minted token where they'd otherwise type a
+		// password, mirroring the HTTP gateway's token query parameter.
+		PasswordCallback: func(conn ssh.ConnMetadata, token []byte) (*ssh.Permissions, error) {
+			id, err := s.auth.AuthenticateToken(conn, string(token))
+			if err != nil {
+				return nil, err
+			}
+			return identityPermissions(id), nil
+		},
+	}
+	config.AddHostKey(s.key)
+
+	sc, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return fmt.Errorf("sftp: ssh handshake: %w", err)
+	}
+	defer sc.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			sc.Close()
+		case <-stop:
+		}
+	}()
+
+	id := identityFromPermissions(sc.Permissions)
+	go ssh.DiscardRequests(reqs)
+
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			newChan.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		ch, chReqs, err := newChan.Accept()
+		if err != nil {
+			return fmt.Errorf("sftp: accept channel: %w", err)
+		}
+		go s.serveSession(ctx, ch, chReqs, id)
+	}
+	return nil
+}
+
+func (s *Server) serveSession(ctx context.Context, ch ssh.Channel, reqs <-chan *ssh.Request, id *Identity) {
+	defer ch.Close()
+	for req := range reqs {
+		isSubsystem := req.Type == "subsystem" && subsystemName(req.Payload) == "sftp"
+		req.Reply(isSubsystem, nil)
+		if !isSubsystem {
+			continue
+		}
+		fs := newFileSystem(ctx, s.cli, id)
+		server := sftp.NewRequestServer(ch, sftp.Handlers{
+			FileGet:  fs,
+			FilePut:  fs,
+			FileCmd:  fs,
+			FileList: fs,
+		})
+		server.Serve()
+		return
+	}
+}
+
+// subsystemName decodes the subsystem-request payload's length-prefixed
+// name string (RFC 4254 6.5), returning "" for a malformed payload
+// instead of panicking -- Payload is attacker-controlled SSH channel-
+// request data, so it can't be trusted to be at least 4 bytes long.
+func subsystemName(payload []byte) string {
+	var req struct {
+		Name string
+	}
+	if ssh.Unmarshal(payload, &req) != nil {
+		return ""
+	}
+	return req.Name
+}
+
+func identityPermissions(id *Identity) *ssh.Permissions {
+	extensions := map[string]string{"username": id.Username}
+	if id.Root != nil {
+		key, _ := pathKey(*id.Root)
+		extensions["root"] = key
+	}
+	return &ssh.Permissions{Extensions: extensions}
+}
+
+func identityFromPermissions(perms *ssh.Permissions) *Identity {
+	if perms == nil {
+		return &Identity{}
+	}
+	id := &Identity{Username: perms.Extensions["username"]}
+	if key, ok := perms.Extensions["root"]; ok {
+		root := keybase1.NewPathWithKbfs(keybase1.KBFSPath{Path: key})
+		id.Root = &root
+	}
+	return id
+}
@@ -0,0 +1,117 @@
+// Package simplefs contains hand-written client conveniences layered on
+// top of the generated keybase1.SimpleFSClient RPC surface.
+package simplefs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// chunkSub is one ReadStream caller's subscription: the channel it reads
+// FileChunks from, and the context whose cancellation should abandon the
+// subscription instead of blocking HandleFileChunk forever.
+type chunkSub struct {
+	ch   chan keybase1.FileChunk
+	done <-chan struct{}
+}
+
+// chunkRegistry fans out FileChunk pushes (delivered by the existing
+// notification dispatch, the same path SimpleFSWatchEvent travels) to the
+// per-opID channel a ReadStream caller is waiting on.
+type chunkRegistry struct {
+	sync.Mutex
+	subs map[keybase1.OpID]chunkSub
+}
+
+var chunks = &chunkRegistry{subs: make(map[keybase1.OpID]chunkSub)}
+
+// HandleFileChunk is called by the notification dispatch layer whenever a
+// FileChunk arrives for an in-flight SimpleFSReadStream op. It's the
+// streaming analogue of NotifyRouter's other Handle* methods.
+func HandleFileChunk(opID keybase1.OpID, chunk keybase1.FileChunk) {
+	chunks.Lock()
+	sub, ok := chunks.subs[opID]
+	if ok && chunk.Eof {
+		delete(chunks.subs, opID)
+	}
+	chunks.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case sub.ch <- chunk:
+		if chunk.Eof {
+			close(sub.ch)
+		}
+	case <-sub.done:
+		// The caller's context was canceled while we were waiting to
+		// deliver, so there's no reader left to unblock us; drop the
+		// chunk and deregister rather than blocking forever.
+		chunks.Lock()
+		delete(chunks.subs, opID)
+		chunks.Unlock()
+	}
+}
+
+// ReadStream begins a chunked read of an already-opened SimpleFS path and
+// returns a channel of FileChunks, closed once a chunk with Eof arrives.
+// The channel is unbuffered, so the notification dispatch goroutine
+// delivering chunks blocks until the caller drains the previous one --
+// that's the backpressure the request asked for. If ctx is canceled
+// first, the subscription is torn down instead of leaving HandleFileChunk
+// blocked on a reader that will never come.
+func ReadStream(ctx context.Context, cli keybase1.SimpleFSClient, opID keybase1.OpID, size int64, algo keybase1.ChecksumAlgo) (<-chan keybase1.FileChunk, error) {
+	ch := make(chan keybase1.FileChunk)
+	chunks.Lock()
+	chunks.subs[opID] = chunkSub{ch: ch, done: ctx.Done()}
+	chunks.Unlock()
+
+	if err := cli.SimpleFSReadStream(ctx, keybase1.SimpleFSReadStreamArg{
+		OpID:         opID,
+		Size:         size,
+		ChecksumAlgo: algo,
+	}); err != nil {
+		chunks.Lock()
+		delete(chunks.subs, opID)
+		chunks.Unlock()
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		chunks.Lock()
+		delete(chunks.subs, opID)
+		chunks.Unlock()
+	}()
+
+	return ch, nil
+}
+
+// WriteStream begins a chunked write to an already-opened SimpleFS path,
+// feeding it data from the supplied channel until it's closed or ctx is
+// canceled.
+func WriteStream(ctx context.Context, cli keybase1.SimpleFSClient, opID keybase1.OpID, algo keybase1.ChecksumAlgo, data <-chan []byte) error {
+	if err := cli.SimpleFSWriteStream(ctx, keybase1.SimpleFSWriteStreamArg{OpID: opID, ChecksumAlgo: algo}); err != nil {
+		return err
+	}
+
+	var offset int64
+	for buf := range data {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := cli.SimpleFSWriteStreamChunk(ctx, keybase1.SimpleFSWriteStreamChunkArg{
+			OpID:  opID,
+			Chunk: keybase1.FileChunk{Offset: offset, Data: buf},
+		}); err != nil {
+			return err
+		}
+		offset += int64(len(buf))
+	}
+	return cli.SimpleFSWriteStreamChunk(ctx, keybase1.SimpleFSWriteStreamChunkArg{
+		OpID:  opID,
+		Chunk: keybase1.FileChunk{Offset: offset, Eof: true},
+	})
+}
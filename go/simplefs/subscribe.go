@@ -0,0 +1,129 @@
+package simplefs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// pathSub tracks one SimpleFSSubscribePath subscription: the channel
+// events are delivered on, and the last-delivered time per topic so
+// HandleFSPathEvent can honor DeduplicateIntervalSecond per (path, topic)
+// pair even though the server may re-send before the caller has drained
+// the previous event for that pair.
+type pathSub struct {
+	ch       chan keybase1.FSPathEvent
+	interval time.Duration
+	done     <-chan struct{}
+
+	mu   sync.Mutex
+	last map[pathTopicKey]time.Time
+}
+
+type pathTopicKey struct {
+	path  string
+	topic keybase1.PathSubscriptionTopic
+}
+
+type subscriptionRegistry struct {
+	sync.Mutex
+	subs map[string]*pathSub
+}
+
+var subscriptions = &subscriptionRegistry{subs: make(map[string]*pathSub)}
+
+// HandleFSPathEvent is called by the notification dispatch layer whenever
+// an FSPathEvent arrives for a path subscription, the same way
+// HandleFileChunk feeds ReadStream. Events arriving within the
+// subscription's DeduplicateIntervalSecond window of the last delivered
+// event for the same (path, topic bit) pair are dropped.
+//
+// The send races sub.done so a subscriber that has stopped reading (the
+// caller walked away, or closeFn already ran) can't wedge delivery to
+// every other subscription forever -- the same hazard HandleFileChunk
+// guards against for stream.go's chunkSub.
+func HandleFSPathEvent(subscriptionID string, event keybase1.FSPathEvent) {
+	subscriptions.Lock()
+	sub, ok := subscriptions.subs[subscriptionID]
+	subscriptions.Unlock()
+	if !ok {
+		return
+	}
+
+	if !sub.shouldDeliver(event) {
+		return
+	}
+
+	select {
+	case sub.ch <- event:
+	case <-sub.done:
+		subscriptions.Lock()
+		delete(subscriptions.subs, subscriptionID)
+		subscriptions.Unlock()
+	}
+}
+
+func (s *pathSub) shouldDeliver(event keybase1.FSPathEvent) bool {
+	if s.interval <= 0 {
+		return true
+	}
+	key := pathTopicKey{path: event.Path.Kbfs().Path, topic: event.Type}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.last[key]; ok && event.Time.Time().Sub(last) < s.interval {
+		return false
+	}
+	s.last[key] = event.Time.Time()
+	return true
+}
+
+// SubscribePath registers a SimpleFSSubscribePath subscription for one or
+// more topics OR'd together (or PathSubscriptionTopic_ALL), and returns
+// the channel FSPathEvents will arrive on. Callers must call the returned
+// close func (or Unsubscribe with the same subscriptionID) when done.
+func SubscribePath(ctx context.Context, cli keybase1.SimpleFSClient, subscriptionID, kbfsPath string, topic keybase1.PathSubscriptionTopic, dedupeIntervalSecond int) (<-chan keybase1.FSPathEvent, func() error, error) {
+	sub := &pathSub{
+		ch:       make(chan keybase1.FSPathEvent),
+		interval: time.Duration(dedupeIntervalSecond) * time.Second,
+		done:     ctx.Done(),
+		last:     make(map[pathTopicKey]time.Time),
+	}
+	subscriptions.Lock()
+	subscriptions.subs[subscriptionID] = sub
+	subscriptions.Unlock()
+
+	if err := cli.SimpleFSSubscribePath(ctx, keybase1.SimpleFSSubscribePathArg{
+		SubscriptionID:            subscriptionID,
+		KbfsPath:                  kbfsPath,
+		Topic:                     topic,
+		DeduplicateIntervalSecond: dedupeIntervalSecond,
+	}); err != nil {
+		subscriptions.Lock()
+		delete(subscriptions.subs, subscriptionID)
+		subscriptions.Unlock()
+		return nil, nil, err
+	}
+
+	// If ctx is canceled before the caller calls closeFn, drop the
+	// subscription ourselves so a pending HandleFSPathEvent send doesn't
+	// block forever waiting on a reader that's already gone.
+	go func() {
+		<-ctx.Done()
+		subscriptions.Lock()
+		delete(subscriptions.subs, subscriptionID)
+		subscriptions.Unlock()
+	}()
+
+	closeFn := func() error {
+		subscriptions.Lock()
+		delete(subscriptions.subs, subscriptionID)
+		subscriptions.Unlock()
+		return cli.SimpleFSUnsubscribe(context.Background(), keybase1.SimpleFSUnsubscribeArg{
+			SubscriptionID: subscriptionID,
+		})
+	}
+	return sub.ch, closeFn, nil
+}
@@ -0,0 +1,70 @@
+package trace
+
+import (
+	"context"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// Copy wraps SimpleFSCopy in a span, since it's the RPC most likely to be
+// slow enough (block-server fetches, journal flushes, mdserver
+// round-trips) that an operator wants it correlated against server-side
+// traces.
+func Copy(ctx context.Context, cli keybase1.SimpleFSClient, arg keybase1.SimpleFSCopyArg) error {
+	ctx, end := startSpan(ctx, "SimpleFSCopy")
+	err := cli.SimpleFSCopy(ctx, arg)
+	end(err)
+	return err
+}
+
+// List wraps SimpleFSList in a span.
+func List(ctx context.Context, cli keybase1.SimpleFSClient, arg keybase1.SimpleFSListArg) error {
+	ctx, end := startSpan(ctx, "SimpleFSList")
+	err := cli.SimpleFSList(ctx, arg)
+	end(err)
+	return err
+}
+
+// Wait wraps SimpleFSWait in a span covering however long the op denoted
+// by opID takes to finish.
+func Wait(ctx context.Context, cli keybase1.SimpleFSClient, opID keybase1.OpID) error {
+	ctx, end := startSpan(ctx, "SimpleFSWait")
+	err := cli.SimpleFSWait(ctx, opID)
+	end(err)
+	return err
+}
+
+// SyncStatus wraps SimpleFSSyncStatus in a span.
+func SyncStatus(ctx context.Context, cli keybase1.SimpleFSClient, filter keybase1.ListFilter) (keybase1.FSSyncStatus, error) {
+	ctx, end := startSpan(ctx, "SimpleFSSyncStatus")
+	res, err := cli.SimpleFSSyncStatus(ctx, filter)
+	end(err)
+	return res, err
+}
+
+// GetStats wraps SimpleFSGetStats in a span.
+func GetStats(ctx context.Context, cli keybase1.SimpleFSClient) (keybase1.SimpleFSStats, error) {
+	ctx, end := startSpan(ctx, "SimpleFSGetStats")
+	res, err := cli.SimpleFSGetStats(ctx)
+	end(err)
+	return res, err
+}
+
+// Subscribe wraps simplefs.SubscribePath's underlying SimpleFSSubscribePath
+// call in a span covering registration; the subscription's lifetime
+// (events arriving over its lifetime) is intentionally not one long span,
+// since that would never end until Unsubscribe is called.
+func Subscribe(ctx context.Context, cli keybase1.SimpleFSClient, arg keybase1.SimpleFSSubscribePathArg) error {
+	ctx, end := startSpan(ctx, "SimpleFSSubscribePath")
+	err := cli.SimpleFSSubscribePath(ctx, arg)
+	end(err)
+	return err
+}
+
+// Unsubscribe wraps SimpleFSUnsubscribe in a span.
+func Unsubscribe(ctx context.Context, cli keybase1.SimpleFSClient, arg keybase1.SimpleFSUnsubscribeArg) error {
+	ctx, end := startSpan(ctx, "SimpleFSUnsubscribe")
+	err := cli.SimpleFSUnsubscribe(ctx, arg)
+	end(err)
+	return err
+}
@@ -0,0 +1,78 @@
+// Package trace wraps a handful of SimpleFS client calls -- the ones an
+// operator actually reaches for when chasing a slow `fs cp` or `fs ls` --
+// in OpenTelemetry spans, and threads the resulting W3C trace context
+// through config.ConfigInfo the same way timeouts and bandwidth caps are
+// threaded (see go/simplefs/config), rather than adding a TraceContext
+// field to every *Arg type. The RPC layer is expected to pick TraceParent/
+// TraceState back up off the context and send them as framed-msgpack-rpc
+// headers; that plumbing lives outside this package.
+package trace
+
+import (
+	"context"
+
+	"github.com/keybase/client/go/simplefs/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies this package's spans in whatever OTLP backend
+// FSSettings.TraceOTLPEndpoint points at.
+const tracerName = "keybase.io/simplefs"
+
+var propagator = propagation.TraceContext{}
+
+// carrier adapts config.ConfigInfo to propagation.TextMapCarrier so the
+// standard W3C TraceContext propagator can read/write its TraceParent and
+// TraceState fields directly, instead of this package hand-rolling the
+// traceparent header format.
+type carrier struct{ info *config.ConfigInfo }
+
+func (c carrier) Get(key string) string {
+	switch key {
+	case "traceparent":
+		return c.info.TraceParent
+	case "tracestate":
+		return c.info.TraceState
+	default:
+		return ""
+	}
+}
+
+func (c carrier) Set(key, value string) {
+	switch key {
+	case "traceparent":
+		c.info.TraceParent = value
+	case "tracestate":
+		c.info.TraceState = value
+	}
+}
+
+func (c carrier) Keys() []string { return []string{"traceparent", "tracestate"} }
+
+// startSpan starts a span named name, attaches a fresh config.ConfigInfo
+// carrying the resulting W3C trace context to ctx, and returns both along
+// with an end func that records err (if any) and finishes the span.
+// Callers pass the returned ctx to the wrapped SimpleFS RPC.
+func startSpan(ctx context.Context, name string) (context.Context, func(err error)) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name)
+	ctx, info := config.AddConfig(ctx)
+	propagator.Inject(ctx, carrier{info})
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// TraceID returns the trace ID of the span started by startSpan on ctx, or
+// the zero value if ctx carries no active span. The CLI's --trace flag
+// prints this so operators can paste it straight into Jaeger/Tempo.
+func TraceID(ctx context.Context) trace.TraceID {
+	return trace.SpanContextFromContext(ctx).TraceID()
+}